@@ -3,12 +3,16 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
 	"github.com/guigui42/mcp-vosdroits/internal/config"
 	"github.com/guigui42/mcp-vosdroits/internal/tools"
@@ -17,6 +21,10 @@ import (
 
 var version = "dev"
 
+// shutdownTimeout bounds how long the HTTP transport waits for in-flight
+// requests to complete after a shutdown signal.
+const shutdownTimeout = 10 * time.Second
+
 func main() {
 	if err := run(); err != nil {
 		log.Fatal(err)
@@ -35,6 +43,11 @@ func run() error {
 	// Set up logging
 	setupLogging(cfg.LogLevel)
 
+	// Dispatch subcommands
+	if len(os.Args) > 1 && os.Args[1] == "snapshot" {
+		return runSnapshot(cfg, os.Args[2:])
+	}
+
 	// Create context with cancellation
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -62,12 +75,20 @@ func run() error {
 		return fmt.Errorf("failed to register tools: %w", err)
 	}
 
+	// Register prompts guiding the model through common procedures
+	tools.RegisterPrompts(server)
+
 	slog.Info("Starting MCP server",
 		"name", cfg.ServerName,
 		"version", cfg.ServerVersion,
 	)
 
-	// Use stdio transport
+	// Use streamable HTTP transport when a port is configured
+	if cfg.HTTPPort != "" {
+		return runHTTP(ctx, server, cfg.HTTPPort)
+	}
+
+	// Otherwise use stdio transport
 	transport := &mcp.StdioTransport{}
 	slog.Info("Using stdio transport")
 
@@ -79,6 +100,61 @@ func run() error {
 	return nil
 }
 
+// runHTTP serves the MCP streamable HTTP transport on the given port until ctx
+// is cancelled, then shuts the HTTP server down gracefully.
+func runHTTP(ctx context.Context, server *mcp.Server, port string) error {
+	// Every session shares the same server and therefore the same scraping clients
+	handler := mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server {
+		return server
+	}, &mcp.StreamableHTTPOptions{
+		Logger: slog.Default(),
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle("/mcp", handler)
+
+	addr := port
+	if !strings.Contains(addr, ":") {
+		addr = ":" + addr
+	}
+
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		slog.Info("Using streamable HTTP transport", "addr", addr, "path", "/mcp")
+		errCh <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return fmt.Errorf("http server error: %w", err)
+	case <-ctx.Done():
+	}
+
+	// Stop accepting new sessions and let in-flight requests finish
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		// Long-lived SSE streams do not end on their own, so force them closed
+		slog.Warn("Graceful shutdown timed out, closing remaining connections", "error", err)
+		if err := httpServer.Close(); err != nil {
+			return fmt.Errorf("failed to close http server: %w", err)
+		}
+	}
+
+	slog.Info("HTTP server stopped")
+	return nil
+}
+
 func setupLogging(level string) {
 	var logLevel slog.Level
 	switch level {
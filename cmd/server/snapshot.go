@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/guigui42/mcp-vosdroits/internal/client"
+	"github.com/guigui42/mcp-vosdroits/internal/config"
+)
+
+// searchLimit is the number of results recorded for each search, the
+// default limit of the search tools.
+const searchLimit = 10
+
+// runSnapshot implements the "snapshot" command, which crawls the pages
+// needed to serve the tools offline and writes them to an archive file.
+func runSnapshot(cfg *config.Config, args []string) error {
+	fs := flag.NewFlagSet("snapshot", flag.ContinueOnError)
+	output := fs.String("o", "vosdroits-snapshot.json.gz", "path of the snapshot archive to write")
+	fiches := fs.String("fiches", "", "comma-separated service-public.gouv.fr fiches to include (IDs like F1342, paths or URLs)")
+	impotsPages := fs.String("impots", "", "comma-separated impots.gouv.fr pages to include (paths or URLs)")
+	searches := fs.String("searches", "", "comma-separated search_procedures queries whose results to include")
+	impotsSearches := fs.String("impots-searches", "", "comma-separated search_impots queries whose results to include")
+	categoryDepth := fs.Int("category-depth", 1, "levels of category pages to include for browse_category: 1 records the themes list_categories returns, 2 also their subthemes, and so on")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s snapshot [flags]\n\n", os.Args[0])
+		fmt.Fprintln(fs.Output(), "Crawls life events, categories and their pages, and the listed pages and searches into an")
+		fmt.Fprintln(fs.Output(), "archive that the server can serve offline when SNAPSHOT_FILE points to it.")
+		fmt.Fprintln(fs.Output())
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Crawl as politely as the server does
+	crawl := client.WithCrawl(client.CrawlConfig{
+		UserAgent:    cfg.CrawlUserAgent,
+		Delay:        cfg.CrawlDelay,
+		Parallelism:  cfg.CrawlParallelism,
+		IgnoreRobots: !cfg.RespectRobotsTxt,
+	})
+
+	snap := client.NewSnapshot()
+	spClient := client.New(cfg.HTTPTimeout, crawl, client.WithSnapshotRecorder(snap))
+	impotsClient := client.NewImpotsClient(cfg.HTTPTimeout, crawl, client.WithSnapshotRecorder(snap))
+
+	var failed int
+	record := func(what string, err error) {
+		if err != nil {
+			failed++
+			slog.Warn("Failed to record page", "page", what, "error", err)
+			return
+		}
+		slog.Info("Recorded page", "page", what)
+	}
+
+	// Listings
+	categories, err := spClient.ListCategories(ctx)
+	record("service-public categories", err)
+
+	// Category pages, so browse_category works offline down to categoryDepth
+	var level []string
+	for _, category := range categories {
+		if category.URL != "" {
+			level = append(level, category.URL)
+		}
+	}
+	seen := make(map[string]bool)
+	for depth := 1; depth <= *categoryDepth && len(level) > 0; depth++ {
+		var next []string
+		for _, categoryURL := range level {
+			if seen[categoryURL] {
+				continue
+			}
+			seen[categoryURL] = true
+
+			page, err := spClient.BrowseCategory(ctx, categoryURL)
+			record(categoryURL, err)
+			if err != nil {
+				continue
+			}
+			for _, sub := range page.Subcategories {
+				next = append(next, sub.URL)
+			}
+		}
+		level = next
+	}
+
+	_, err = impotsClient.ListImpotsCategories(ctx)
+	record("impots categories", err)
+
+	events, err := spClient.ListLifeEvents(ctx)
+	record("life events", err)
+
+	// Every life event page, so get_life_event_details works offline
+	for _, event := range events {
+		_, err := spClient.GetLifeEventDetails(ctx, event.URL)
+		record(event.URL, err)
+	}
+
+	for _, fiche := range splitList(*fiches) {
+		if !strings.Contains(fiche, "/") {
+			fiche = "/particuliers/vosdroits/" + fiche
+		}
+		_, err := spClient.GetArticle(ctx, fiche)
+		record(fiche, err)
+	}
+
+	for _, page := range splitList(*impotsPages) {
+		_, err := impotsClient.GetImpotsArticle(ctx, page)
+		record(page, err)
+	}
+
+	// Searches are served offline only for the recorded queries
+	for _, query := range splitList(*searches) {
+		_, err := spClient.SearchProcedures(ctx, query, searchLimit)
+		record("search "+query, err)
+	}
+
+	for _, query := range splitList(*impotsSearches) {
+		_, err := impotsClient.SearchImpots(ctx, query, searchLimit)
+		record("impots search "+query, err)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("snapshot interrupted: %w", err)
+	}
+
+	if err := snap.Save(*output); err != nil {
+		return err
+	}
+
+	slog.Info("Snapshot written",
+		"path", *output,
+		"pages", snap.Len(),
+		"failed", failed,
+		"created_at", snap.CreatedAt,
+	)
+	return nil
+}
+
+// splitList splits a comma-separated flag value, dropping empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
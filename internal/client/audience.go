@@ -0,0 +1,104 @@
+package client
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Audience selects the space of service-public.gouv.fr a Client browses.
+type Audience string
+
+const (
+	// AudienceParticuliers is the space for individuals, the default.
+	AudienceParticuliers Audience = "particuliers"
+	// AudienceProfessionnels is the space for businesses, hosted on
+	// entreprendre.service-public.gouv.fr.
+	AudienceProfessionnels Audience = "professionnels"
+	// AudienceAssociations is the space for associations.
+	AudienceAssociations Audience = "associations"
+)
+
+// entreprendreBaseURL is the website of the professionnels space.
+const entreprendreBaseURL = "https://entreprendre.service-public.gouv.fr"
+
+// ParseAudience returns the audience named s. An empty s selects
+// AudienceParticuliers.
+func ParseAudience(s string) (Audience, error) {
+	switch a := Audience(strings.ToLower(strings.TrimSpace(s))); a {
+	case "":
+		return AudienceParticuliers, nil
+	case AudienceParticuliers, AudienceProfessionnels, AudienceAssociations:
+		return a, nil
+	}
+	return "", errorf(ErrInvalidInput, "audience must be %q, %q or %q, got %q", AudienceParticuliers, AudienceProfessionnels, AudienceAssociations, s)
+}
+
+// ForAudience returns a client browsing the space of audience a. It shares
+// the rate limit, caches and form index of c.
+func (c *Client) ForAudience(a Audience) *Client {
+	scoped := *c
+	scoped.audience = a
+	return &scoped
+}
+
+// Audience returns the audience whose space the client browses.
+func (c *Client) Audience() Audience {
+	if c.audience == "" {
+		return AudienceParticuliers
+	}
+	return c.audience
+}
+
+// spacePath returns the path prefix of the audience's pages on its website.
+func (c *Client) spacePath() string {
+	switch c.Audience() {
+	case AudienceProfessionnels:
+		return ""
+	case AudienceAssociations:
+		return "/associations"
+	default:
+		return "/particuliers"
+	}
+}
+
+// spaceURL returns the root URL of the audience's space, without a trailing
+// slash. Pages of the space live under spaceURL()+"/vosdroits/".
+func (c *Client) spaceURL() string {
+	if c.Audience() == AudienceProfessionnels {
+		return c.entreprendreURL
+	}
+	return c.fetcher.site.baseURL + c.spacePath()
+}
+
+// homeURL returns the home page of the audience's space.
+func (c *Client) homeURL() string {
+	if c.Audience() == AudienceProfessionnels {
+		return c.entreprendreURL + "/"
+	}
+	return c.spaceURL()
+}
+
+// FicheURL returns the URL of the page whose identifier is id in the
+// audience's space. id is a fiche, category, question-réponse or resource
+// identifier such as F1342, N358, F1342Q or R45813, in any case; other
+// identifiers fail with ErrInvalidInput.
+func (c *Client) FicheURL(id string) (string, error) {
+	id = strings.ToUpper(strings.TrimSpace(id))
+	if !ficheIDPattern.MatchString(id) {
+		return "", errorf(ErrInvalidInput, "invalid page identifier %q, expected e.g. F1342, F1342Q or N358", id)
+	}
+	return c.spaceURL() + "/vosdroits/" + id, nil
+}
+
+// resolve validates rawURL like fetcher.resolve. Relative URLs of the
+// professionnels space, such as /vosdroits/F23282, are resolved against
+// entreprendre.service-public.gouv.fr.
+func (c *Client) resolve(rawURL string) (string, *url.URL, error) {
+	if c.Audience() == AudienceProfessionnels &&
+		strings.HasPrefix(rawURL, "/") &&
+		!strings.HasPrefix(rawURL, "/particuliers/") &&
+		!strings.HasPrefix(rawURL, "/associations/") {
+		rawURL = c.entreprendreURL + rawURL
+	}
+	return c.fetcher.resolve(rawURL)
+}
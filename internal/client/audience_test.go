@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseAudience(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Audience
+		wantErr bool
+	}{
+		{"", AudienceParticuliers, false},
+		{"particuliers", AudienceParticuliers, false},
+		{" Professionnels ", AudienceProfessionnels, false},
+		{"associations", AudienceAssociations, false},
+		{"entreprises", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseAudience(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseAudience(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseAudience(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveForAudience(t *testing.T) {
+	c := New(5 * time.Second)
+	pro := c.ForAudience(AudienceProfessionnels)
+
+	tests := []struct {
+		name   string
+		client *Client
+		input  string
+		want   string
+	}{
+		{"particuliers fiche", c, "/particuliers/vosdroits/F1342", "https://www.service-public.gouv.fr/particuliers/vosdroits/F1342"},
+		{"professionnels fiche", pro, "/vosdroits/F23282", "https://entreprendre.service-public.gouv.fr/vosdroits/F23282"},
+		{"particuliers fiche from professionnels", pro, "/particuliers/vosdroits/F1342", "https://www.service-public.gouv.fr/particuliers/vosdroits/F1342"},
+		{"absolute entreprendre URL", c, "https://entreprendre.service-public.gouv.fr/vosdroits/F23282", "https://entreprendre.service-public.gouv.fr/vosdroits/F23282"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := tt.client.resolve(tt.input)
+			if err != nil {
+				t.Fatalf("resolve(%q) error = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("resolve(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+
+	if c.Audience() != AudienceParticuliers {
+		t.Errorf("ForAudience() changed the original client's audience to %q", c.Audience())
+	}
+}
+
+func TestFicheURL(t *testing.T) {
+	c := New(5 * time.Second)
+
+	tests := []struct {
+		audience Audience
+		want     string
+	}{
+		{AudienceParticuliers, "https://www.service-public.gouv.fr/particuliers/vosdroits/F1342"},
+		{AudienceProfessionnels, "https://entreprendre.service-public.gouv.fr/vosdroits/F1342"},
+		{AudienceAssociations, "https://www.service-public.gouv.fr/associations/vosdroits/F1342"},
+	}
+
+	for _, tt := range tests {
+		if got, err := c.ForAudience(tt.audience).FicheURL("F1342"); err != nil || got != tt.want {
+			t.Errorf("FicheURL(%s) = %q, %v, want %q", tt.audience, got, err, tt.want)
+		}
+	}
+
+	// Every identifier of a link to the site is accepted, in any case
+	for _, id := range []string{"F1342Q", "f1342q", "N358", "R45813"} {
+		if _, err := c.FicheURL(id); err != nil {
+			t.Errorf("FicheURL(%q) error = %v", id, err)
+		}
+	}
+	for _, id := range []string{"", "..", "F", "X1342", "F1342/../N358"} {
+		if _, err := c.FicheURL(id); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("FicheURL(%q) error = %v, want ErrInvalidInput", id, err)
+		}
+	}
+}
+
+func TestAudienceFixture(t *testing.T) {
+	ctx := context.Background()
+	c, server := newFixtureClient(t)
+	pro := c.ForAudience(AudienceProfessionnels)
+
+	results, err := pro.SearchProcedures(ctx, "micro-entreprise", 10)
+	if err != nil {
+		t.Fatalf("SearchProcedures() error = %v", err)
+	}
+	if len(results) != 1 || results[0].URL != server.URL+"/vosdroits/F23282" {
+		t.Fatalf("SearchProcedures() = %+v", results)
+	}
+
+	article, err := pro.GetArticle(ctx, "/vosdroits/F23282")
+	if err != nil {
+		t.Fatalf("GetArticle() error = %v", err)
+	}
+	if article.Title != "Micro-entrepreneur : formalités de création" {
+		t.Errorf("Title = %q", article.Title)
+	}
+
+	proCategories, err := pro.ListCategories(ctx)
+	if err != nil {
+		t.Fatalf("ListCategories(professionnels) error = %v", err)
+	}
+	if len(proCategories) != 2 || proCategories[0].URL != server.URL+"/vosdroits/N24264" {
+		t.Errorf("ListCategories(professionnels) = %+v", proCategories)
+	}
+
+	// Only the themes of the associations space are listed
+	assoCategories, err := c.ForAudience(AudienceAssociations).ListCategories(ctx)
+	if err != nil {
+		t.Fatalf("ListCategories(associations) error = %v", err)
+	}
+	if len(assoCategories) != 1 || assoCategories[0].Name != "Création d'une association" {
+		t.Errorf("ListCategories(associations) = %+v", assoCategories)
+	}
+
+	// The particuliers space is unaffected
+	categories, err := c.ListCategories(ctx)
+	if err != nil {
+		t.Fatalf("ListCategories(particuliers) error = %v", err)
+	}
+	if categories[0].Name != "Papiers - Citoyenneté - Élections" {
+		t.Errorf("ListCategories(particuliers) = %+v", categories)
+	}
+}
+
+func TestListLifeEventsAudience(t *testing.T) {
+	c := New(5*time.Second, WithBaseURL("http://127.0.0.1:1"))
+
+	// Only particuliers has life events; nothing is fetched for the others
+	for _, a := range []Audience{AudienceProfessionnels, AudienceAssociations} {
+		if _, err := c.ForAudience(a).ListLifeEvents(context.Background()); !errors.Is(err, ErrInvalidInput) {
+			t.Errorf("ListLifeEvents(%s) error = %v, want ErrInvalidInput", a, err)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is wrapped by the errors of requests that were not sent
+// because their host failed repeatedly. Such errors are of kind
+// ErrUpstreamUnavailable.
+var ErrCircuitOpen = errors.New("site temporarily unavailable")
+
+// BreakerConfig configures the per-host circuit breaker.
+type BreakerConfig struct {
+	// Threshold is the number of consecutive failed requests to a host after
+	// which requests to it fail immediately. Zero disables the breaker.
+	Threshold int
+	// Cooldown is how long requests fail immediately before a single trial
+	// request is sent to find out whether the host has recovered.
+	Cooldown time.Duration
+}
+
+// DefaultBreakerConfig is the circuit breaker used unless WithBreaker is given.
+var DefaultBreakerConfig = BreakerConfig{
+	Threshold: 5,
+	Cooldown:  30 * time.Second,
+}
+
+// breakerTransport is an http.RoundTripper that stops contacting a host after
+// consecutive transient failures, so that calls fail at once instead of each
+// waiting for the timeout while the website is down. Failures are counted
+// after retries.
+type breakerTransport struct {
+	base http.RoundTripper
+	cfg  BreakerConfig
+	now  func() time.Time
+
+	mu    sync.Mutex
+	hosts map[string]*circuit
+}
+
+// circuit is the state of the breaker for a single host.
+type circuit struct {
+	failures  int
+	openUntil time.Time
+	// probing is set while the trial request of a half-open circuit is in
+	// flight.
+	probing bool
+}
+
+// newBreakerTransport wraps base with a circuit breaker per host.
+func newBreakerTransport(base http.RoundTripper, cfg BreakerConfig) *breakerTransport {
+	return &breakerTransport{
+		base:  base,
+		cfg:   cfg,
+		now:   time.Now,
+		hosts: make(map[string]*circuit),
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *breakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+	probe, err := t.allow(host)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil && (errors.Is(err, context.Canceled) || callerDone(req)) {
+		// Says nothing about the host
+		if probe {
+			t.release(host)
+		}
+		return resp, err
+	}
+	// The HTTP client reports its own timeout as a cancellation
+	timedOut := err != nil && errors.Is(req.Context().Err(), context.DeadlineExceeded)
+	// A classified error, such as a 429 with a long Retry-After, counts when
+	// it is transient
+	t.record(host, timedOut || isTransient(resp, err) || Retryable(err))
+	return resp, err
+}
+
+// allow returns an error if requests to host must not be sent now. Otherwise
+// it reports whether the request is the trial request of a half-open circuit.
+func (t *breakerTransport) allow(host string) (bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	c := t.hosts[host]
+	if c == nil || c.openUntil.IsZero() {
+		return false, nil
+	}
+
+	now := t.now()
+	if now.Before(c.openUntil) {
+		retryAfter := c.openUntil.Sub(now)
+		return false, &Error{
+			Kind:       ErrUpstreamUnavailable,
+			RetryAfter: retryAfter,
+			Err: fmt.Errorf("%w: %s failed %d times in a row, next attempt in %s",
+				ErrCircuitOpen, host, c.failures, retryAfter.Round(time.Second)),
+		}
+	}
+	if c.probing {
+		// The cooldown is over: the outcome of the trial request decides
+		return false, &Error{
+			Kind: ErrUpstreamUnavailable,
+			Err: fmt.Errorf("%w: %s failed %d times in a row, a trial request is checking whether it has recovered",
+				ErrCircuitOpen, host, c.failures),
+		}
+	}
+
+	// Half-open: let a single trial request through
+	c.probing = true
+	return true, nil
+}
+
+// release forgets the trial request of host, which ended without an answer.
+func (t *breakerTransport) release(host string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if c := t.hosts[host]; c != nil {
+		c.probing = false
+	}
+}
+
+// record updates the circuit of host with the outcome of a request.
+func (t *breakerTransport) record(host string, failed bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	c := t.hosts[host]
+	if c == nil {
+		c = &circuit{}
+		t.hosts[host] = c
+	}
+
+	if !failed {
+		if !c.openUntil.IsZero() {
+			slog.Info("Circuit closed, host recovered", "host", host)
+		}
+		*c = circuit{}
+		return
+	}
+
+	c.failures++
+	if c.probing || c.failures >= t.cfg.Threshold {
+		c.openUntil = t.now().Add(t.cfg.Cooldown)
+		c.probing = false
+		slog.Warn("Circuit opened, host temporarily unavailable", "host", host, "failures", c.failures, "cooldown", t.cfg.Cooldown)
+	}
+}
+
+// open reports whether requests to host are currently failing fast. host
+// includes the port, if any, as in a request URL.
+func (t *breakerTransport) open(host string) bool {
+	if t == nil {
+		return false
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	c := t.hosts[host]
+	return c != nil && (t.now().Before(c.openUntil) || c.probing)
+}
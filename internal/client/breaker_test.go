@@ -0,0 +1,303 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyServer answers with 503 while down is set, and with the fixture pages
+// otherwise. It counts the requests it receives.
+type flakyServer struct {
+	*httptest.Server
+	down  atomic.Bool
+	calls atomic.Int32
+}
+
+func newFlakyServer(t *testing.T, pages map[string]string) *flakyServer {
+	t.Helper()
+
+	fixtures := newFixtureServer(t, pages)
+	s := &flakyServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/robots.txt" {
+			s.calls.Add(1)
+		}
+		if s.down.Load() {
+			http.Error(w, "maintenance", http.StatusServiceUnavailable)
+			return
+		}
+		fixtures.Config.Handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func TestBreakerTransport(t *testing.T) {
+	server := newFlakyServer(t, servicePublicPages)
+	server.down.Store(true)
+
+	transport := newBreakerTransport(http.DefaultTransport, BreakerConfig{Threshold: 2, Cooldown: time.Minute})
+	now := time.Now()
+	transport.now = func() time.Time { return now }
+	httpClient := &http.Client{Transport: transport}
+	pageURL := server.URL + "/particuliers/vosdroits/F1342"
+
+	get := func() (int, error) {
+		resp, err := httpClient.Get(pageURL)
+		if err != nil {
+			return 0, err
+		}
+		resp.Body.Close()
+		return resp.StatusCode, nil
+	}
+
+	for range 2 {
+		if status, err := get(); err != nil || status != http.StatusServiceUnavailable {
+			t.Fatalf("Get() = %d, %v; want the 503 of the website", status, err)
+		}
+	}
+	if !transport.open(server.Listener.Addr().String()) {
+		t.Fatal("circuit should be open after 2 consecutive failures")
+	}
+
+	// Open: fail fast without contacting the website
+	_, err := get()
+	if !errors.Is(err, ErrCircuitOpen) || !errors.Is(err, ErrUpstreamUnavailable) {
+		t.Fatalf("Get() error = %v, want ErrCircuitOpen of kind ErrUpstreamUnavailable", err)
+	}
+	var clientErr *Error
+	if !errors.As(err, &clientErr) || clientErr.RetryAfter != time.Minute {
+		t.Errorf("RetryAfter = %v, want the cooldown", clientErr)
+	}
+	if server.calls.Load() != 2 {
+		t.Errorf("website received %d requests, want 2", server.calls.Load())
+	}
+
+	// Half-open: a successful trial request closes the circuit
+	server.down.Store(false)
+	now = now.Add(time.Minute)
+	if status, err := get(); err != nil || status != http.StatusOK {
+		t.Fatalf("Get() = %d, %v; want 200 after the cooldown", status, err)
+	}
+	if transport.open(server.Listener.Addr().String()) {
+		t.Error("circuit should be closed after a successful trial request")
+	}
+}
+
+func TestBreakerTransportReopens(t *testing.T) {
+	server := newFlakyServer(t, servicePublicPages)
+	server.down.Store(true)
+
+	transport := newBreakerTransport(http.DefaultTransport, BreakerConfig{Threshold: 3, Cooldown: time.Minute})
+	now := time.Now()
+	transport.now = func() time.Time { return now }
+
+	get := func() error {
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/particuliers", nil)
+		resp, err := transport.RoundTrip(req)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	for range 3 {
+		_ = get()
+	}
+	now = now.Add(time.Minute)
+
+	// A single failed trial request reopens the circuit
+	if err := get(); err != nil {
+		t.Fatalf("trial request error = %v, want it sent", err)
+	}
+	if err := get(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("error after failed trial = %v, want ErrCircuitOpen", err)
+	}
+	if server.calls.Load() != 4 {
+		t.Errorf("website received %d requests, want 4", server.calls.Load())
+	}
+}
+
+func TestBreakerTransportProbing(t *testing.T) {
+	transport := newBreakerTransport(http.DefaultTransport, BreakerConfig{Threshold: 1, Cooldown: time.Minute})
+	now := time.Now()
+	transport.now = func() time.Time { return now }
+
+	transport.record("example.test", true)
+	now = now.Add(time.Minute)
+	if probe, err := transport.allow("example.test"); err != nil || !probe {
+		t.Fatalf("allow() = %v, %v; want the trial request let through", probe, err)
+	}
+
+	// Other requests wait for the trial request, without a stale countdown
+	_, err := transport.allow("example.test")
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("allow() error = %v, want ErrCircuitOpen", err)
+	}
+	if msg := err.Error(); !strings.Contains(msg, "trial request") || strings.Contains(msg, "0s") {
+		t.Errorf("allow() error = %q, want the probe state", msg)
+	}
+}
+
+func TestBreakerTransportPerHost(t *testing.T) {
+	down := newFlakyServer(t, servicePublicPages)
+	down.down.Store(true)
+	up := newFlakyServer(t, servicePublicPages)
+
+	transport := newBreakerTransport(http.DefaultTransport, BreakerConfig{Threshold: 1, Cooldown: time.Minute})
+	for _, server := range []*flakyServer{down, up} {
+		req, _ := http.NewRequest(http.MethodGet, server.URL+"/particuliers", nil)
+		if resp, err := transport.RoundTrip(req); err == nil {
+			resp.Body.Close()
+		}
+	}
+
+	// An outage of one host does not mark the other as failing
+	if !transport.open(down.Listener.Addr().String()) {
+		t.Error("circuit of the failing host should be open")
+	}
+	if transport.open(up.Listener.Addr().String()) {
+		t.Error("circuit of the healthy host should be closed")
+	}
+}
+
+func TestBreakerTransportIgnoresCancellation(t *testing.T) {
+	transport := newBreakerTransport(http.DefaultTransport, BreakerConfig{Threshold: 1, Cooldown: time.Minute})
+	server := newFlakyServer(t, servicePublicPages)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/particuliers", nil)
+	if _, err := transport.RoundTrip(req); !errors.Is(err, context.Canceled) {
+		t.Fatalf("RoundTrip() error = %v, want context.Canceled", err)
+	}
+	if transport.open(server.Listener.Addr().String()) {
+		t.Error("a cancelled request should not open the circuit")
+	}
+}
+
+func TestBreakerTransportKeepsProbeOnOtherCancellation(t *testing.T) {
+	started := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-r.Context().Done()
+	}))
+	t.Cleanup(server.Close)
+	host := server.Listener.Addr().String()
+
+	transport := newBreakerTransport(http.DefaultTransport, BreakerConfig{Threshold: 1, Cooldown: time.Minute})
+	now := time.Now()
+	transport.now = func() time.Time { return now }
+
+	// A request is in flight while the circuit opens and turns half-open
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		if resp, err := transport.RoundTrip(req); err == nil {
+			resp.Body.Close()
+		}
+	}()
+	<-started
+	transport.record(host, true)
+	now = now.Add(time.Minute)
+	if probe, err := transport.allow(host); err != nil || !probe {
+		t.Fatalf("allow() = %v, %v; want the trial request let through", probe, err)
+	}
+
+	// Its cancellation does not let a second trial request through
+	cancel()
+	<-done
+	if _, err := transport.allow(host); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("allow() error = %v, want ErrCircuitOpen while the trial request runs", err)
+	}
+}
+
+func TestBreakerTransportIgnoresCallerDeadline(t *testing.T) {
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	t.Cleanup(slow.Close)
+	host := slow.Listener.Addr().String()
+
+	get := func(ctx context.Context, timeout time.Duration) error {
+		transport := newBreakerTransport(http.DefaultTransport, BreakerConfig{Threshold: 1, Cooldown: time.Minute})
+		httpClient := &http.Client{Transport: transport, Timeout: timeout}
+		req, _ := http.NewRequestWithContext(withCaller(ctx), http.MethodGet, slow.URL, nil)
+		if _, err := httpClient.Do(req); err == nil {
+			t.Fatal("Do() should time out")
+		}
+		if transport.open(host) {
+			return errors.New("circuit opened")
+		}
+		return nil
+	}
+
+	// A caller giving up early says nothing about the host
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := get(ctx, 0); err != nil {
+		t.Errorf("caller deadline: %v, want the circuit closed", err)
+	}
+
+	// The request timing out does
+	if err := get(context.Background(), 20*time.Millisecond); err == nil {
+		t.Error("request timeout: circuit closed, want it open")
+	}
+}
+
+func TestClientServesStaleWhileCircuitOpen(t *testing.T) {
+	server := newFlakyServer(t, servicePublicPages)
+	c := New(5*time.Second,
+		WithBaseURL(server.URL),
+		WithRetry(RetryConfig{MaxAttempts: 1}),
+		WithBreaker(BreakerConfig{Threshold: 1, Cooldown: time.Minute}),
+		WithCache(CacheConfig{MaxEntries: 10, ArticleTTL: time.Hour}),
+	)
+	now := time.Now()
+	c.fetcher.cache.now = func() time.Time { return now }
+	ctx := context.Background()
+	articleURL := server.URL + "/particuliers/vosdroits/F1342"
+
+	fresh, err := c.GetArticle(ctx, articleURL)
+	if err != nil {
+		t.Fatalf("GetArticle() error = %v", err)
+	}
+
+	// The cached article expires, then the website goes down
+	now = now.Add(24 * time.Hour)
+	server.down.Store(true)
+
+	for i := range 2 {
+		stale, err := c.GetArticle(ctx, articleURL)
+		if err != nil {
+			t.Fatalf("GetArticle() #%d error = %v, want the stale article", i+1, err)
+		}
+		if stale.Title != fresh.Title {
+			t.Errorf("Title = %q, want %q", stale.Title, fresh.Title)
+		}
+	}
+	if !c.Degraded() {
+		t.Error("Degraded() = false while the circuit is open")
+	}
+	if server.calls.Load() != 2 {
+		t.Errorf("website received %d requests, want 2: the open circuit should fail fast", server.calls.Load())
+	}
+
+	// Nothing cached: the caller gets the circuit error
+	_, err = c.GetArticle(ctx, server.URL+"/particuliers/vosdroits/F1343")
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("GetArticle() error = %v, want ErrCircuitOpen", err)
+	}
+}
@@ -0,0 +1,252 @@
+package client
+
+import (
+	"container/list"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+)
+
+// CacheConfig configures the in-memory cache of scraped results. A zero TTL
+// disables caching for that kind of operation.
+type CacheConfig struct {
+	// MaxEntries bounds the number of cached results; the least recently
+	// used entry is evicted first.
+	MaxEntries int
+	// ListTTL applies to category and life event listings, which rarely change.
+	ListTTL time.Duration
+	// SearchTTL applies to search results.
+	SearchTTL time.Duration
+	// ArticleTTL applies to articles and life event details.
+	ArticleTTL time.Duration
+}
+
+// operation identifies the kind of cached result, which determines its TTL.
+type operation string
+
+const (
+	opList    operation = "list"
+	opSearch  operation = "search"
+	opArticle operation = "article"
+)
+
+// cache is a size-bounded LRU cache whose entries expire after a TTL that
+// depends on the operation that produced them. Expired entries are kept until
+// evicted, to be served as stale content while a website is unavailable.
+// Values are copied when stored and when returned, so that a caller
+// modifying a result changes neither the cache nor what other callers get. A
+// nil *cache is valid and caches nothing.
+type cache struct {
+	name string
+	cfg  CacheConfig
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type cacheEntry struct {
+	key     string
+	value   any
+	expires time.Time
+}
+
+// newCache returns a cache for the named site, or nil if cfg disables caching.
+func newCache(name string, cfg CacheConfig) *cache {
+	if cfg.MaxEntries <= 0 {
+		return nil
+	}
+	return &cache{
+		name:    name,
+		cfg:     cfg,
+		now:     time.Now,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+func (c *cache) ttl(op operation) time.Duration {
+	switch op {
+	case opList:
+		return c.cfg.ListTTL
+	case opSearch:
+		return c.cfg.SearchTTL
+	case opArticle:
+		return c.cfg.ArticleTTL
+	}
+	return 0
+}
+
+// get returns the live value stored under key.
+func (c *cache) get(op operation, key string) (any, bool) {
+	if c == nil || c.ttl(op) <= 0 {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok || c.now().After(elem.Value.(*cacheEntry).expires) {
+		slog.Debug("Cache miss", "site", c.name, "operation", op, "key", key)
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+	slog.Debug("Cache hit", "site", c.name, "operation", op, "key", key)
+	return cloneValue(elem.Value.(*cacheEntry).value), true
+}
+
+// set stores value under key with the TTL of op, evicting the least
+// recently used entry if the cache is full.
+func (c *cache) set(op operation, key string, value any) {
+	if c == nil || c.ttl(op) <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	value = cloneValue(value)
+	expires := c.now().Add(c.ttl(op))
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*cacheEntry)
+		entry.value = value
+		entry.expires = expires
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, value: value, expires: expires})
+
+	for c.order.Len() > c.cfg.MaxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// stale returns the value stored under key, even if it has expired.
+func (c *cache) stale(key string) (any, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	return cloneValue(elem.Value.(*cacheEntry).value), true
+}
+
+// fromCache returns the value of type T stored under key.
+func fromCache[T any](c *cache, op operation, key string) (T, bool) {
+	value, ok := c.get(op, key)
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	typed, ok := value.(T)
+	return typed, ok
+}
+
+// fromStaleCache returns the value of type T stored under key, even if it has
+// expired, when err means the website is unavailable: outdated content is
+// more useful than none while it is down.
+func fromStaleCache[T any](c *cache, key string, err error) (T, bool) {
+	var zero T
+	if !Retryable(err) {
+		return zero, false
+	}
+
+	value, ok := c.stale(key)
+	if !ok {
+		return zero, false
+	}
+	typed, ok := value.(T)
+	if ok {
+		slog.Warn("Serving stale cached result", "site", c.name, "key", key, "error", err)
+	}
+	return typed, ok
+}
+
+// cloneValue returns a deep copy of value if it is one of the results cached
+// by the clients, and value itself otherwise.
+func cloneValue(value any) any {
+	switch v := value.(type) {
+	case *Article:
+		article := *v
+		article.Sections = cloneSections(v.Sections)
+		article.Tables = cloneTables(v.Tables)
+		article.Links = slices.Clone(v.Links)
+		return &article
+	case *ImpotsArticle:
+		article := *v
+		article.Tables = cloneTables(v.Tables)
+		article.Links = slices.Clone(v.Links)
+		return &article
+	case *LifeEventDetails:
+		details := *v
+		details.Sections = slices.Clone(v.Sections)
+		for i := range details.Sections {
+			details.Sections[i].Tables = cloneTables(v.Sections[i].Tables)
+		}
+		return &details
+	case *CategoryPage:
+		page := *v
+		page.Parents = slices.Clone(v.Parents)
+		page.Subcategories = slices.Clone(v.Subcategories)
+		page.Fiches = slices.Clone(v.Fiches)
+		return &page
+	case *PDFDocument:
+		doc := *v
+		doc.Pages = slices.Clone(v.Pages)
+		return &doc
+	case *resultsPage[SearchResult]:
+		return v.clone()
+	case *resultsPage[ImpotsSearchResult]:
+		return v.clone()
+	case []CategoryInfo:
+		return slices.Clone(v)
+	case []LifeEvent:
+		return slices.Clone(v)
+	case []ImpotsCategoryInfo:
+		return slices.Clone(v)
+	}
+	return value
+}
+
+// cloneSections returns a deep copy of sections.
+func cloneSections(sections []ArticleSection) []ArticleSection {
+	sections = slices.Clone(sections)
+	for i := range sections {
+		sections[i].Paragraphs = slices.Clone(sections[i].Paragraphs)
+		sections[i].Lists = cloneRows(sections[i].Lists)
+		sections[i].Sections = cloneSections(sections[i].Sections)
+	}
+	return sections
+}
+
+// cloneTables returns a deep copy of tables.
+func cloneTables(tables []Table) []Table {
+	tables = slices.Clone(tables)
+	for i := range tables {
+		tables[i].Headers = slices.Clone(tables[i].Headers)
+		tables[i].Rows = cloneRows(tables[i].Rows)
+	}
+	return tables
+}
+
+// cloneRows returns a deep copy of rows.
+func cloneRows(rows [][]string) [][]string {
+	rows = slices.Clone(rows)
+	for i := range rows {
+		rows[i] = slices.Clone(rows[i])
+	}
+	return rows
+}
@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheExpiry(t *testing.T) {
+	c := newCache("test", CacheConfig{
+		MaxEntries: 10,
+		ListTTL:    time.Hour,
+		SearchTTL:  time.Minute,
+	})
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	c.set(opList, "categories", []string{"a"})
+	c.set(opSearch, "search:10:impots", []string{"b"})
+
+	now = now.Add(2 * time.Minute)
+
+	if _, ok := c.get(opList, "categories"); !ok {
+		t.Error("list entry should still be cached")
+	}
+	if _, ok := c.get(opSearch, "search:10:impots"); ok {
+		t.Error("search entry should have expired")
+	}
+}
+
+func TestCacheEviction(t *testing.T) {
+	c := newCache("test", CacheConfig{MaxEntries: 2, ArticleTTL: time.Hour})
+
+	c.set(opArticle, "a", 1)
+	c.set(opArticle, "b", 2)
+
+	// Touch "a" so that "b" becomes the least recently used entry
+	if _, ok := c.get(opArticle, "a"); !ok {
+		t.Fatal("entry a should be cached")
+	}
+	c.set(opArticle, "c", 3)
+
+	if _, ok := c.get(opArticle, "b"); ok {
+		t.Error("least recently used entry should have been evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := c.get(opArticle, key); !ok {
+			t.Errorf("entry %s should be cached", key)
+		}
+	}
+}
+
+func TestCacheDisabled(t *testing.T) {
+	if c := newCache("test", CacheConfig{}); c != nil {
+		t.Fatal("newCache() with no entries should return nil")
+	}
+
+	// A nil cache and a zero TTL both cache nothing
+	var nilCache *cache
+	nilCache.set(opList, "key", 1)
+	if _, ok := nilCache.get(opList, "key"); ok {
+		t.Error("nil cache should never hit")
+	}
+
+	c := newCache("test", CacheConfig{MaxEntries: 10, ListTTL: time.Hour})
+	c.set(opSearch, "key", 1)
+	if _, ok := c.get(opSearch, "key"); ok {
+		t.Error("operation with zero TTL should not be cached")
+	}
+}
+
+func TestClientCachesListings(t *testing.T) {
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/robots.txt" {
+			requests.Add(1)
+		}
+		data, err := os.ReadFile(filepath.Join("testdata", servicePublicPages[r.URL.Path]))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	c := New(5*time.Second, WithBaseURL(server.URL), WithCache(CacheConfig{
+		MaxEntries: 10,
+		ListTTL:    time.Hour,
+		ArticleTTL: time.Hour,
+	}))
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.ListLifeEvents(ctx); err != nil {
+			t.Fatalf("ListLifeEvents() error = %v", err)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("ListLifeEvents() made %d requests, want 1", got)
+	}
+
+	// Failed fetches are not cached
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetArticle(ctx, server.URL+"/particuliers/vosdroits/F0000"); err == nil {
+			t.Fatal("GetArticle() should fail for a missing page")
+		}
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("made %d requests, want 3", got)
+	}
+}
+
+func TestFromStaleCache(t *testing.T) {
+	c := newCache("test", CacheConfig{MaxEntries: 10, ArticleTTL: time.Minute})
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	c.set(opArticle, "article:a", "content")
+	now = now.Add(time.Hour)
+
+	if _, ok := fromCache[string](c, opArticle, "article:a"); ok {
+		t.Fatal("expired entry should not be served fresh")
+	}
+
+	unavailable := errorf(ErrUpstreamUnavailable, "503")
+	if got, ok := fromStaleCache[string](c, "article:a", unavailable); !ok || got != "content" {
+		t.Errorf("fromStaleCache() = %q, %v; want the expired entry", got, ok)
+	}
+	if _, ok := fromStaleCache[string](c, "article:a", errorf(ErrNotFound, "404")); ok {
+		t.Error("stale entries should only be served while the website is unavailable")
+	}
+	if _, ok := fromStaleCache[string](nil, "article:a", unavailable); ok {
+		t.Error("nil cache should never hit")
+	}
+}
+
+func TestCacheReturnsCopies(t *testing.T) {
+	server := newFixtureServer(t, servicePublicPages)
+	c := New(5*time.Second, WithBaseURL(server.URL), fixtureCrawl, WithCache(CacheConfig{MaxEntries: 10, ArticleTTL: time.Hour}))
+	ctx := context.Background()
+
+	first, err := c.GetArticle(ctx, "/particuliers/vosdroits/F1342")
+	if err != nil {
+		t.Fatalf("GetArticle() error = %v", err)
+	}
+	title, paragraph := first.Title, first.Sections[0].Paragraphs[0]
+
+	// Modifying a result changes neither the cache nor later results
+	first.Title = "modified"
+	first.Sections[0].Paragraphs[0] = "modified"
+	second, err := c.GetArticle(ctx, "/particuliers/vosdroits/F1342")
+	if err != nil {
+		t.Fatalf("GetArticle() error = %v", err)
+	}
+	if second == first || second.Title != title || second.Sections[0].Paragraphs[0] != paragraph {
+		t.Errorf("GetArticle() = %q, %q after modifying an earlier result", second.Title, second.Sections[0].Paragraphs[0])
+	}
+
+	// So are stale results
+	second.Sections[0].Paragraphs[0] = "modified"
+	stale, ok := fromStaleCache[*Article](c.fetcher.cache, "article:"+second.URL, errorf(ErrUpstreamUnavailable, "503"))
+	if !ok || stale.Sections[0].Paragraphs[0] != paragraph {
+		t.Errorf("fromStaleCache() = %v, %v", stale, ok)
+	}
+}
@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/gocolly/colly/v2"
+)
+
+// CategoryPage is a service-public.gouv.fr category page (N-page) with the
+// links needed to walk the taxonomy.
+type CategoryPage struct {
+	Title       string
+	URL         string
+	Description string
+	// Parents lists the enclosing categories, from the breadcrumb.
+	Parents []Link
+	// Subcategories lists the subthemes of the category (N-pages).
+	Subcategories []Link
+	// Fiches lists the fiches and questions-réponses of the category.
+	Fiches []Link
+}
+
+// isCategoryPath reports whether p is the path of a category page.
+func isCategoryPath(p string) bool {
+	m := ficheIDPattern.FindStringSubmatch(path.Base(p))
+	return strings.Contains(p, "/vosdroits/") && m != nil && m[1] == "N"
+}
+
+// BrowseCategory retrieves the category page at categoryURL, such as
+// /particuliers/vosdroits/N19808, with its subcategories and fiches.
+func (c *Client) BrowseCategory(ctx context.Context, categoryURL string) (*CategoryPage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	categoryURL, parsedURL, err := c.resolve(categoryURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isCategoryPath(parsedURL.Path) {
+		return nil, errorf(ErrInvalidInput, "URL must be a category page (/vosdroits/N...). Use get_article for fiches (F-prefix). Got: %s", categoryURL)
+	}
+
+	cacheKey := "category:" + categoryURL
+	if cached, ok := fromCache[*CategoryPage](c.fetcher.cache, opList, cacheKey); ok {
+		return cached, nil
+	}
+
+	page := CategoryPage{URL: categoryURL}
+
+	err = c.fetcher.visit(ctx, categoryURL, func(scraper *colly.Collector) {
+		scraper.OnHTML("h1", func(e *colly.HTMLElement) {
+			if page.Title == "" {
+				page.Title = strings.TrimSpace(e.Text)
+			}
+		})
+
+		scraper.OnHTML("div#intro p, p.fr-text--lg", func(e *colly.HTMLElement) {
+			if text := strings.TrimSpace(e.Text); text != "" && page.Description == "" {
+				page.Description = text
+			}
+		})
+
+		scraper.OnHTML("nav.fr-breadcrumb", func(e *colly.HTMLElement) {
+			for _, link := range parseLinks(e, "a[href]") {
+				if link.Kind == LinkCategory && link.URL != categoryURL {
+					page.Parents = append(page.Parents, link)
+				}
+			}
+		})
+
+		scraper.OnHTML("main", func(e *colly.HTMLElement) {
+			for _, link := range parseLinks(e, "a[href]") {
+				switch link.Kind {
+				case LinkCategory:
+					page.Subcategories = append(page.Subcategories, link)
+				case LinkFiche, LinkQuestion:
+					page.Fiches = append(page.Fiches, link)
+				}
+			}
+		})
+	})
+	if err != nil {
+		if stale, ok := fromStaleCache[*CategoryPage](c.fetcher.cache, cacheKey, err); ok {
+			return stale, nil
+		}
+		return nil, err
+	}
+
+	// The breadcrumb and self links are not subcategories
+	page.Subcategories = slices.DeleteFunc(page.Subcategories, func(link Link) bool {
+		return link.URL == categoryURL || slices.ContainsFunc(page.Parents, func(parent Link) bool {
+			return parent.URL == link.URL
+		})
+	})
+
+	if page.Title == "" {
+		page.Title = "Category from service-public.gouv.fr"
+	}
+	if len(page.Subcategories) == 0 && len(page.Fiches) == 0 {
+		return nil, errorf(ErrParseFailure, "no subcategories or fiches found at URL: %s", categoryURL)
+	}
+
+	c.fetcher.cache.set(opList, cacheKey, &page)
+	return &page, nil
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestBrowseCategoryFixture(t *testing.T) {
+	ctx := context.Background()
+	c, server := newFixtureClient(t)
+
+	// Walk from the theme list down to a fiche
+	categories, err := c.ListCategories(ctx)
+	if err != nil {
+		t.Fatalf("ListCategories() error = %v", err)
+	}
+	if categories[0].URL != server.URL+"/particuliers/vosdroits/N19803" {
+		t.Fatalf("first category URL = %q", categories[0].URL)
+	}
+
+	theme, err := c.BrowseCategory(ctx, categories[0].URL)
+	if err != nil {
+		t.Fatalf("BrowseCategory(theme) error = %v", err)
+	}
+	if theme.Title != "Papiers - Citoyenneté - Élections" || !strings.HasPrefix(theme.Description, "Carte d'identité") {
+		t.Errorf("theme = %q, %q", theme.Title, theme.Description)
+	}
+	if len(theme.Parents) != 0 {
+		t.Errorf("theme Parents = %+v, want none", theme.Parents)
+	}
+	if len(theme.Subcategories) != 2 || theme.Subcategories[0].Text != "Papiers d'identité" || theme.Subcategories[1].Text != "Élections" {
+		t.Fatalf("theme Subcategories = %+v", theme.Subcategories)
+	}
+
+	sub, err := c.BrowseCategory(ctx, theme.Subcategories[0].URL)
+	if err != nil {
+		t.Fatalf("BrowseCategory(subtheme) error = %v", err)
+	}
+	if len(sub.Parents) != 1 || sub.Parents[0].URL != theme.URL {
+		t.Errorf("subtheme Parents = %+v", sub.Parents)
+	}
+	if len(sub.Subcategories) != 0 {
+		t.Errorf("subtheme Subcategories = %+v, want none", sub.Subcategories)
+	}
+	if len(sub.Fiches) != 3 || sub.Fiches[2].Kind != LinkQuestion {
+		t.Fatalf("subtheme Fiches = %+v", sub.Fiches)
+	}
+
+	article, err := c.GetArticle(ctx, sub.Fiches[0].URL)
+	if err != nil {
+		t.Fatalf("GetArticle(fiche) error = %v", err)
+	}
+	if article.Title != sub.Fiches[0].Text {
+		t.Errorf("fiche title = %q, want %q", article.Title, sub.Fiches[0].Text)
+	}
+}
+
+func TestBrowseCategoryRejectsFiches(t *testing.T) {
+	c, _ := newFixtureClient(t)
+
+	_, err := c.BrowseCategory(context.Background(), "/particuliers/vosdroits/F1342")
+	if !errors.Is(err, ErrInvalidInput) || !strings.Contains(err.Error(), "category page") {
+		t.Errorf("BrowseCategory(fiche) error = %v, want category page error", err)
+	}
+}
@@ -11,48 +11,76 @@ import (
 	"github.com/gocolly/colly/v2"
 )
 
+// servicePublic describes service-public.gouv.fr, its legacy domain, the
+// entreprendre.service-public.gouv.fr space for professionals and the
+// formulaires.service-public.gouv.fr host of the Cerfa forms.
+var servicePublic = site{
+	name:    "service-public.gouv.fr",
+	baseURL: "https://www.service-public.gouv.fr",
+	hosts: []string{
+		"www.service-public.gouv.fr",
+		"service-public.gouv.fr",
+		"www.service-public.fr",
+		"service-public.fr",
+		"entreprendre.service-public.gouv.fr",
+		"entreprendre.service-public.fr",
+		"www.formulaires.service-public.gouv.fr",
+		"formulaires.service-public.gouv.fr",
+	},
+}
+
 // Client handles HTTP requests to service-public.gouv.fr using Colly for web scraping.
 type Client struct {
-	collector *colly.Collector
-	baseURL   string
-	timeout   time.Duration
+	fetcher  *fetcher
+	forms    *formIndex
+	audience Audience
+	// entreprendreURL is the base URL of the professionnels space.
+	entreprendreURL string
 }
 
-// New creates a new Client with the specified timeout.
-func New(timeout time.Duration) *Client {
-	// Create a new Colly collector with configuration
-	c := colly.NewCollector(
-		colly.AllowedDomains(
-			"www.service-public.gouv.fr",
-			"service-public.gouv.fr",
-			"www.service-public.fr",
-			"service-public.fr",
-		),
-		colly.UserAgent("VosDroits-MCP-Server/1.0"),
-		colly.Async(false),
-	)
-
-	// Set timeout
-	c.SetRequestTimeout(timeout)
-
-	// Configure rate limiting to be respectful
-	err := c.Limit(&colly.LimitRule{
-		DomainGlob:  "*.service-public.*",
-		Parallelism: 1,
-		Delay:       1 * time.Second,
-	})
-	if err != nil {
-		// Fallback without rate limiting if it fails
-		// This shouldn't happen, but we handle it gracefully
+// New creates a new Client with the specified timeout. The client browses
+// the particuliers space; use ForAudience for the other spaces.
+func New(timeout time.Duration, opts ...Option) *Client {
+	o := newOptions(opts)
+
+	// A base URL override serves every space, e.g. from a fixture server
+	entreprendreURL := entreprendreBaseURL
+	if o.baseURL != "" {
+		entreprendreURL = o.baseURL
 	}
 
 	return &Client{
-		collector: c,
-		baseURL:   "https://www.service-public.gouv.fr",
-		timeout:   timeout,
+		fetcher:         newFetcher(servicePublic, timeout, o),
+		forms:           newFormIndex(o.cache.MaxEntries),
+		audience:        AudienceParticuliers,
+		entreprendreURL: entreprendreURL,
 	}
 }
 
+// Name returns the canonical domain of service-public.gouv.fr.
+func (c *Client) Name() string {
+	return c.fetcher.site.name
+}
+
+// Owns reports whether rawURL is an absolute URL on service-public.gouv.fr,
+// in any of its spaces.
+func (c *Client) Owns(rawURL string) bool {
+	return c.fetcher.owns(rawURL)
+}
+
+// SnapshotDate returns when the offline snapshot served by the client was
+// taken, or the zero time when pages are fetched live from service-public.gouv.fr.
+func (c *Client) SnapshotDate() time.Time {
+	return c.fetcher.snapshotDate()
+}
+
+// Degraded reports whether the website of the client's audience is failing
+// and requests to it are short-circuited, so that results come from the
+// cache and may be outdated.
+func (c *Client) Degraded() bool {
+	return c.fetcher.degraded(c.homeURL())
+}
+
 // SearchResult represents a search result.
 type SearchResult struct {
 	Title       string
@@ -60,8 +88,24 @@ type SearchResult struct {
 	Description string
 }
 
-// SearchProcedures searches for procedures matching the query.
+// SearchProcedures searches for procedures matching the query. It fails if
+// the website cannot be reached and returns no results if nothing matches.
 func (c *Client) SearchProcedures(ctx context.Context, query string, limit int) ([]SearchResult, error) {
+	page, err := c.SearchProceduresPage(ctx, query, limit, "")
+	if err != nil {
+		return nil, err
+	}
+	if page.EmptyReason == EmptySiteError || page.EmptyReason == EmptyOffline {
+		return nil, page.Err
+	}
+	return page.Results, nil
+}
+
+// SearchProceduresPage returns a page of up to limit procedures matching the
+// query, following the site's pagination as needed. An empty cursor starts at
+// the first result; pass SearchPage.NextCursor to get the following page.
+// When there are no results, SearchPage.EmptyReason says why.
+func (c *Client) SearchProceduresPage(ctx context.Context, query string, limit int, cursor string) (*SearchPage[SearchResult], error) {
 	// Check context cancellation
 	if err := ctx.Err(); err != nil {
 		return nil, err
@@ -72,117 +116,118 @@ func (c *Client) SearchProcedures(ctx context.Context, query string, limit int)
 		limit = 10
 	}
 
-	var results []SearchResult
-	errorChan := make(chan error, 1)
+	// Build search URL - each space has its own search, e.g. /particuliers/recherche
+	searchURL := fmt.Sprintf("%s/recherche?keyword=%s", c.spaceURL(), url.QueryEscape(query))
+	start := searchCursor{Search: searchURL, URL: searchURL, Page: 1}
+	if cursor != "" {
+		var err error
+		if start, err = c.fetcher.resumeSearch(start, cursor, c.spacePath()+"/recherche"); err != nil {
+			return nil, err
+		}
+	}
 
-	// Clone the collector to avoid conflicts
-	scraper := c.collector.Clone()
+	page, err := collectResults(ctx, start, limit, c.searchPage)
+	if err != nil {
+		return nil, err
+	}
+	return offlineResults(c.fetcher, page), nil
+}
 
-	// Allow URL revisits to prevent "already visited" errors on repeated calls
-	scraper.AllowURLRevisit = true
+// searchPage scrapes one page of search results.
+func (c *Client) searchPage(ctx context.Context, pageURL string) (*resultsPage[SearchResult], error) {
+	// Serve repeated searches from the cache
+	cacheKey := "search:" + pageURL
+	if page, ok := fromCache[*resultsPage[SearchResult]](c.fetcher.cache, opSearch, cacheKey); ok {
+		return page, nil
+	}
 
-	// Set up context cancellation
-	done := make(chan struct{})
-	defer close(done)
+	var page resultsPage[SearchResult]
 
-	go func() {
-		select {
-		case <-ctx.Done():
-			// Context cancelled, close the collector
-			scraper = nil
-		case <-done:
-			// Operation completed normally
-		}
-	}()
+	err := c.fetcher.visit(ctx, pageURL, func(scraper *colly.Collector) {
+		// Handle search results - service-public.gouv.fr uses <li> with id pattern "result_*"
+		scraper.OnHTML("li[id^='result_']", func(e *colly.HTMLElement) {
+			// Extract URL and title from the link
+			href := e.ChildAttr("a.fr-link", "href")
+			if href == "" {
+				return
+			}
 
-	// Handle search results - service-public.gouv.fr uses <li> with id pattern "result_*"
-	scraper.OnHTML("li[id^='result_']", func(e *colly.HTMLElement) {
-		if len(results) >= limit {
-			return
-		}
+			// Make URL absolute
+			fullURL := e.Request.AbsoluteURL(href)
 
-		// Extract URL and title from the link
-		href := e.ChildAttr("a.fr-link", "href")
-		if href == "" {
-			return
-		}
+			// Extract title - get the innermost span text to avoid duplication
+			var title string
+			e.ForEach("a.fr-link span span", func(_ int, elem *colly.HTMLElement) {
+				if title == "" {
+					title = strings.TrimSpace(elem.Text)
+				}
+			})
+			// Fallback if no nested span found
+			if title == "" {
+				title = strings.TrimSpace(e.ChildText("a.fr-link"))
+			}
 
-		// Make URL absolute
-		fullURL := e.Request.AbsoluteURL(href)
+			// Extract description if available (some results may have descriptions)
+			description := strings.TrimSpace(e.ChildText(".sp-description, .description"))
 
-		// Extract title - get the innermost span text to avoid duplication
-		var title string
-		e.ForEach("a.fr-link span span", func(_ int, elem *colly.HTMLElement) {
-			if title == "" {
-				title = strings.TrimSpace(elem.Text)
+			if title != "" && fullURL != "" {
+				page.items = append(page.items, SearchResult{
+					Title:       title,
+					URL:         fullURL,
+					Description: description,
+				})
 			}
 		})
-		// Fallback if no nested span found
-		if title == "" {
-			title = strings.TrimSpace(e.ChildText("a.fr-link"))
-		}
 
-		// Extract description if available (some results may have descriptions)
-		description := strings.TrimSpace(e.ChildText(".sp-description, .description"))
+		// Total number of results, e.g. "23 résultats"
+		scraper.OnHTML("h1, h2, [class*='results-count'], [class*='nb-result']", func(e *colly.HTMLElement) {
+			if n, ok := parseResultCount(e.Text); ok && page.total == 0 {
+				page.total = n
+			}
+		})
 
-		if title != "" && fullURL != "" {
-			results = append(results, SearchResult{
-				Title:       title,
-				URL:         fullURL,
-				Description: description,
+		// The "no results" message and the alternative queries it offers
+		scraper.OnHTML("main", func(e *colly.HTMLElement) {
+			page.noMatch = isNoResultsText(e.Text)
+			e.ForEach(suggestionSelector, func(_ int, elem *colly.HTMLElement) {
+				page.suggestions = appendUnique(page.suggestions, elem.Text)
 			})
-		}
-	})
+		})
 
-	// Handle errors
-	scraper.OnError(func(r *colly.Response, err error) {
-		select {
-		case errorChan <- fmt.Errorf("scraping error: %w", err):
-		default:
-		}
+		// Link to the next page of results
+		scraper.OnHTML("a.fr-pagination__link--next[href], a[rel='next'][href]", func(e *colly.HTMLElement) {
+			if page.next == "" {
+				page.next = e.Request.AbsoluteURL(e.Attr("href"))
+			}
+		})
 	})
-
-	// Build search URL - service-public.gouv.fr uses /particuliers/recherche
-	searchURL := fmt.Sprintf("%s/particuliers/recherche?keyword=%s", c.baseURL, url.QueryEscape(query))
-
-	// Visit the search page
-	if err := scraper.Visit(searchURL); err != nil {
-		// If the search fails, return fallback results
-		return c.fallbackSearch(ctx, query, limit)
+	if err != nil && len(page.items) == 0 {
+		if stale, ok := fromStaleCache[*resultsPage[SearchResult]](c.fetcher.cache, cacheKey, err); ok {
+			return stale, nil
+		}
+		return nil, err
 	}
 
-	// Wait for scraping to complete
-	scraper.Wait()
+	c.fetcher.cache.set(opSearch, cacheKey, &page)
+	return &page, nil
+}
 
-	// Check for errors
-	select {
-	case err := <-errorChan:
-		if len(results) == 0 {
-			return nil, err
-		}
-		// If we got some results, return them despite the error
-	default:
+// Search implements Source by delegating to SearchProcedures.
+func (c *Client) Search(ctx context.Context, query string, limit int) ([]Result, error) {
+	results, err := c.SearchProcedures(ctx, query, limit)
+	if err != nil {
+		return nil, err
 	}
 
-	// If no results found, try alternative search
-	if len(results) == 0 {
-		return c.fallbackSearch(ctx, query, limit)
+	out := make([]Result, len(results))
+	for i, r := range results {
+		out[i] = Result{
+			Title:       r.Title,
+			URL:         r.URL,
+			Description: r.Description,
+		}
 	}
-
-	return results, nil
-}
-
-// fallbackSearch provides a fallback search method
-func (c *Client) fallbackSearch(ctx context.Context, query string, limit int) ([]SearchResult, error) {
-	// For now, return a helpful message
-	// In a real implementation, we could try different search strategies
-	return []SearchResult{
-		{
-			Title:       fmt.Sprintf("No results found for: %s", query),
-			URL:         fmt.Sprintf("%s/particuliers/recherche?keyword=%s", c.baseURL, url.QueryEscape(query)),
-			Description: "Try modifying your search terms or visit the website directly.",
-		},
-	}, nil
+	return out, nil
 }
 
 // Article represents an article from service-public.gouv.fr.
@@ -190,6 +235,31 @@ type Article struct {
 	Title   string
 	Content string
 	URL     string
+	// Markdown is Content with headings, lists, emphasis, links and tables
+	// kept as Markdown.
+	Markdown string
+	// Sections preserves the heading structure of Content.
+	Sections []ArticleSection
+	// Tables holds the tables of the article, which Content leaves out.
+	Tables []Table
+	// Links holds the outbound links of the article: related fiches,
+	// téléservices, forms and legal texts.
+	Links []Link
+}
+
+// articleMarkdownSelector matches the blocks rendered in Article.Markdown.
+const articleMarkdownSelector = "div#intro p, h2, h3, h4, p[data-test='contenu-texte'], div.sp-section p, div.fr-callout, div.fr-highlight, ul.sp-item-list, div.sp-section ul, div.sp-section ol, table"
+
+// articleSectionSelector matches the headings, paragraphs and lists that make
+// up the section tree of an article.
+const articleSectionSelector = "h2, h3, h4, p[data-test='contenu-texte'], div.sp-section p, div.fr-callout p, ul.sp-item-list, div.sp-section ul, div.sp-section ol"
+
+// isArticleText reports whether text is article content rather than
+// navigation or subscription boilerplate.
+func isArticleText(text string) bool {
+	return !strings.Contains(text, "javascript") &&
+		!strings.Contains(text, "Votre situation") &&
+		!strings.Contains(text, "Abonnement")
 }
 
 // GetArticle retrieves an article from the specified URL.
@@ -199,146 +269,62 @@ func (c *Client) GetArticle(ctx context.Context, articleURL string) (*Article, e
 		return nil, err
 	}
 
-	// Validate URL is not empty
-	if articleURL == "" {
-		return nil, fmt.Errorf("URL cannot be empty")
-	}
-
-	// Validate URL
-	parsedURL, err := url.Parse(articleURL)
+	// Validate URL - accept service-public.gouv.fr and service-public.fr (both with and without www)
+	articleURL, _, err := c.resolve(articleURL)
 	if err != nil {
-		return nil, fmt.Errorf("invalid URL: %w", err)
-	}
-
-	// Ensure it's a service-public.gouv.fr URL (check for empty host or relative URLs)
-	if parsedURL.Host == "" {
-		// Handle relative URLs by making them absolute
-		articleURL = c.baseURL + articleURL
-		parsedURL, err = url.Parse(articleURL)
-		if err != nil {
-			return nil, fmt.Errorf("invalid URL after making absolute: %w", err)
-		}
-	}
-
-	// Check domain - accept service-public.gouv.fr and service-public.fr (both with and without www)
-	host := strings.ToLower(parsedURL.Host)
-	validDomains := []string{
-		"service-public.gouv.fr",
-		"www.service-public.gouv.fr",
-		"service-public.fr",
-		"www.service-public.fr",
-	}
-
-	domainValid := false
-	for _, validDomain := range validDomains {
-		if host == validDomain {
-			domainValid = true
-			break
-		}
+		return nil, err
 	}
 
-	if !domainValid {
-		return nil, fmt.Errorf("URL must be from service-public.gouv.fr or service-public.fr domain, got: %s", parsedURL.Host)
+	cacheKey := "article:" + articleURL
+	if cached, ok := fromCache[*Article](c.fetcher.cache, opArticle, cacheKey); ok {
+		return cached, nil
 	}
 
 	var article Article
 	article.URL = articleURL
-	errorChan := make(chan error, 1)
-
-	// Clone the collector
-	scraper := c.collector.Clone()
-
-	// Allow URL revisits to prevent "already visited" errors on repeated calls
-	scraper.AllowURLRevisit = true
-
-	// Set up context cancellation
-	done := make(chan struct{})
-	defer close(done)
-
-	go func() {
-		select {
-		case <-ctx.Done():
-			scraper = nil
-		case <-done:
-		}
-	}()
-
-	// Extract article title - service-public.gouv.fr uses h1#titlePage
-	scraper.OnHTML("h1#titlePage", func(e *colly.HTMLElement) {
-		if article.Title == "" {
-			article.Title = strings.TrimSpace(e.Text)
-		}
-	})
-
-	// Extract main content - service-public.gouv.fr has content in article.article
-	scraper.OnHTML("article.article", func(e *colly.HTMLElement) {
-		if article.Content == "" {
-			// Get all relevant content elements
-			var contentParts []string
 
-			// Add introduction text
-			intro := strings.TrimSpace(e.ChildText("div#intro p.fr-text--lg"))
-			if intro != "" {
-				contentParts = append(contentParts, intro)
+	err = c.fetcher.visit(ctx, articleURL, func(scraper *colly.Collector) {
+		// Extract article title - service-public.gouv.fr uses h1#titlePage
+		scraper.OnHTML("h1#titlePage", func(e *colly.HTMLElement) {
+			if article.Title == "" {
+				article.Title = strings.TrimSpace(e.Text)
 			}
+		})
 
-			// Add main content sections
-			e.ForEach("h2, h3, p[data-test='contenu-texte'], .fr-text--lg, div.sp-section p, div.fr-callout p", func(_ int, elem *colly.HTMLElement) {
-				text := strings.TrimSpace(elem.Text)
-				// Filter out navigation, scripts, and empty content
-				if text != "" &&
-					!strings.Contains(text, "javascript") &&
-					!strings.Contains(text, "Votre situation") &&
-					!strings.Contains(text, "Abonnement") &&
-					len(text) > 10 {
-					contentParts = append(contentParts, text)
-				}
-			})
+		// Extract main content - service-public.gouv.fr has content in article.article
+		scraper.OnHTML("article.article", func(e *colly.HTMLElement) {
+			if article.Content == "" {
+				// Get all relevant content elements
+				var contentParts []string
 
-			article.Content = strings.Join(contentParts, "\n\n")
-		}
-	})
+				// Add introduction text
+				intro := strings.TrimSpace(e.ChildText("div#intro p.fr-text--lg"))
+				if intro != "" {
+					contentParts = append(contentParts, intro)
+				}
 
-	// Handle HTTP responses to detect 404s and other errors
-	scraper.OnResponse(func(r *colly.Response) {
-		if r.StatusCode == 404 {
-			select {
-			case errorChan <- fmt.Errorf("article not found (404) at URL: %s", r.Request.URL):
-			default:
-			}
-		} else if r.StatusCode >= 400 {
-			select {
-			case errorChan <- fmt.Errorf("HTTP error %d at URL: %s", r.StatusCode, r.Request.URL):
-			default:
+				// Add main content sections
+				e.ForEach("h2, h3, p[data-test='contenu-texte'], .fr-text--lg, div.sp-section p, div.fr-callout p", func(_ int, elem *colly.HTMLElement) {
+					text := strings.TrimSpace(elem.Text)
+					// Filter out navigation, scripts, and empty content
+					if text != "" && isArticleText(text) && len(text) > 10 {
+						contentParts = append(contentParts, text)
+					}
+				})
+
+				article.Content = strings.Join(contentParts, "\n\n")
+				article.Markdown = renderMarkdown(e, articleMarkdownSelector, isArticleText)
+				article.Sections = parseSections(e, articleSectionSelector, isArticleText)
+				article.Tables = parseTables(e, "table")
+				article.Links = parseLinks(e, "a[href]")
 			}
-		}
+		})
 	})
-
-	// Handle errors
-	scraper.OnError(func(r *colly.Response, err error) {
-		statusMsg := ""
-		if r != nil {
-			statusMsg = fmt.Sprintf(" (HTTP %d)", r.StatusCode)
-		}
-		select {
-		case errorChan <- fmt.Errorf("failed to fetch article%s: %w", statusMsg, err):
-		default:
+	if err != nil {
+		if stale, ok := fromStaleCache[*Article](c.fetcher.cache, cacheKey, err); ok {
+			return stale, nil
 		}
-	})
-
-	// Visit the article page
-	if err := scraper.Visit(articleURL); err != nil {
-		return nil, fmt.Errorf("failed to visit article page: %w", err)
-	}
-
-	// Wait for scraping to complete
-	scraper.Wait()
-
-	// Check for errors
-	select {
-	case err := <-errorChan:
 		return nil, err
-	default:
 	}
 
 	// Validate that we got content
@@ -346,16 +332,50 @@ func (c *Client) GetArticle(ctx context.Context, articleURL string) (*Article, e
 		article.Title = "Article from service-public.gouv.fr"
 	}
 	if article.Content == "" {
-		return nil, fmt.Errorf("no content found at URL: %s", articleURL)
+		return nil, errorf(ErrParseFailure, "no content found at URL: %s", articleURL)
 	}
 
+	c.forms.add(&article)
+	c.fetcher.cache.set(opArticle, cacheKey, &article)
 	return &article, nil
 }
 
+// GetPDF retrieves a PDF document from service-public.gouv.fr, such as a
+// Cerfa form, and extracts its text page by page.
+func (c *Client) GetPDF(ctx context.Context, documentURL string) (*PDFDocument, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	documentURL, _, err := c.resolve(documentURL)
+	if err != nil {
+		return nil, err
+	}
+	return c.fetcher.getPDF(ctx, documentURL)
+}
+
+// GetDocument implements Source by delegating to GetArticle.
+func (c *Client) GetDocument(ctx context.Context, documentURL string) (*Document, error) {
+	article, err := c.GetArticle(ctx, documentURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Document{
+		Title:   article.Title,
+		URL:     article.URL,
+		Type:    "Article",
+		Content: article.Content,
+	}, nil
+}
+
 // CategoryInfo represents a service category.
 type CategoryInfo struct {
 	Name        string
 	Description string
+	// URL is the category page, to use with BrowseCategory. It is empty for
+	// the default categories.
+	URL string
 }
 
 // ListCategories retrieves available service categories.
@@ -365,78 +385,53 @@ func (c *Client) ListCategories(ctx context.Context) ([]CategoryInfo, error) {
 		return nil, err
 	}
 
-	var categories []CategoryInfo
-	errorChan := make(chan error, 1)
-
-	// Clone the collector
-	scraper := c.collector.Clone()
-
-	// Allow URL revisits to prevent "already visited" errors on repeated calls
-	scraper.AllowURLRevisit = true
+	cacheKey := "categories:" + string(c.Audience())
+	if categories, ok := fromCache[[]CategoryInfo](c.fetcher.cache, opList, cacheKey); ok {
+		return categories, nil
+	}
 
-	// Set up context cancellation
-	done := make(chan struct{})
-	defer close(done)
+	var categories []CategoryInfo
 
-	go func() {
-		select {
-		case <-ctx.Done():
-			scraper = nil
-		case <-done:
-		}
-	}()
-
-	// Extract main category sections from the footer theme list
-	scraper.OnHTML("ul.sp-theme-list li a.fr-footer__top-link", func(e *colly.HTMLElement) {
-		name := strings.TrimSpace(e.Text)
-		href := e.Attr("href")
-
-		// Filter for main categories (these are the primary themes)
-		if name != "" && len(name) > 3 && strings.Contains(href, "/particuliers/vosdroits/N") {
-			// Avoid duplicates
-			for _, cat := range categories {
-				if cat.Name == name {
-					return
+	// Theme pages of the space, e.g. /particuliers/vosdroits/N19803
+	themePath := c.spacePath() + "/vosdroits/N"
+
+	// Visit the home page of the audience's space
+	err := c.fetcher.visit(ctx, c.homeURL(), func(scraper *colly.Collector) {
+		// Extract main category sections from the footer theme list
+		scraper.OnHTML("ul.sp-theme-list li a.fr-footer__top-link", func(e *colly.HTMLElement) {
+			name := strings.TrimSpace(e.Text)
+			href := e.Attr("href")
+
+			// Filter for main categories (these are the primary themes)
+			if name != "" && len(name) > 3 && strings.Contains(href, themePath) {
+				// Avoid duplicates
+				for _, cat := range categories {
+					if cat.Name == name {
+						return
+					}
 				}
-			}
-
-			categories = append(categories, CategoryInfo{
-				Name:        name,
-				Description: fmt.Sprintf("Information and procedures for %s", strings.ToLower(name)),
-			})
-		}
-	})
 
-	// Handle errors
-	scraper.OnError(func(r *colly.Response, err error) {
-		select {
-		case errorChan <- fmt.Errorf("failed to fetch categories: %w", err):
-		default:
-		}
+				categories = append(categories, CategoryInfo{
+					Name:        name,
+					Description: fmt.Sprintf("Information and procedures for %s", strings.ToLower(name)),
+					URL:         e.Request.AbsoluteURL(href),
+				})
+			}
+		})
 	})
-
-	// Visit the home page for particuliers
-	if err := scraper.Visit(c.baseURL + "/particuliers"); err != nil {
-		// Fallback to default categories if scraping fails
-		return c.getDefaultCategories(), nil
-	}
-
-	// Wait for scraping to complete
-	scraper.Wait()
-
-	// Check for errors
-	select {
-	case <-errorChan:
-		// If there was an error, return default categories
-		return c.getDefaultCategories(), nil
-	default:
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
 	}
 
-	// If no categories found, return default ones
-	if len(categories) == 0 {
+	// If there was an error or no categories were found, return default ones
+	if err != nil || len(categories) == 0 {
+		if stale, ok := fromStaleCache[[]CategoryInfo](c.fetcher.cache, cacheKey, err); ok {
+			return stale, nil
+		}
 		return c.getDefaultCategories(), nil
 	}
 
+	c.fetcher.cache.set(opList, cacheKey, categories)
 	return categories, nil
 }
 
@@ -458,6 +453,20 @@ func (c *Client) getDefaultCategories() []CategoryInfo {
 	}
 }
 
+// Categories implements Source by delegating to ListCategories.
+func (c *Client) Categories(ctx context.Context) ([]Category, error) {
+	categories, err := c.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]Category, len(categories))
+	for i, cat := range categories {
+		out[i] = Category(cat)
+	}
+	return out, nil
+}
+
 // LifeEvent represents a life event situation (événement de vie).
 type LifeEvent struct {
 	Title       string
@@ -466,112 +475,92 @@ type LifeEvent struct {
 }
 
 // ListLifeEvents retrieves all available life events from the "Comment faire si" page.
+// Only the particuliers space publishes life events; other audiences fail
+// with ErrInvalidInput.
 func (c *Client) ListLifeEvents(ctx context.Context) ([]LifeEvent, error) {
 	// Check context cancellation
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
 
-	var events []LifeEvent
-	errorChan := make(chan error, 1)
-
-	// Clone the collector and clear visited URLs to allow re-visiting
-	scraper := c.collector.Clone()
+	if c.Audience() != AudienceParticuliers {
+		return nil, errorf(ErrInvalidInput, "life events are only published for the %s audience, not %s; use search_procedures or list_categories instead", AudienceParticuliers, c.Audience())
+	}
 
-	// Clear visited URLs - this is crucial for allowing the same URL to be visited
-	// multiple times when the tool is called repeatedly
-	scraper.AllowURLRevisit = true
+	cacheKey := "life-events:" + string(c.Audience())
+	if events, ok := fromCache[[]LifeEvent](c.fetcher.cache, opList, cacheKey); ok {
+		return events, nil
+	}
 
-	// Set up context cancellation
-	done := make(chan struct{})
-	defer close(done)
+	var events []LifeEvent
 
-	go func() {
-		select {
-		case <-ctx.Done():
-			scraper = nil
-		case <-done:
-		}
-	}()
-
-	// Extract life event tiles from the main page
-	// The tiles link to fiche pratique pages (F-URLs like F16225)
-	scraper.OnHTML("a.fr-tile__link", func(e *colly.HTMLElement) {
-		// Get the link
-		href := e.Attr("href")
-		if href == "" {
-			return
-		}
+	// Visit the "comment faire si" page
+	err := c.fetcher.visit(ctx, c.spaceURL()+"/vosdroits/comment-faire-si", func(scraper *colly.Collector) {
+		// Extract life event tiles from the main page
+		// The tiles link to fiche pratique pages (F-URLs like F16225)
+		scraper.OnHTML("a.fr-tile__link", func(e *colly.HTMLElement) {
+			// Get the link
+			href := e.Attr("href")
+			if href == "" {
+				return
+			}
 
-		// Only process links that are fiche pratique pages (F-URLs)
-		// Fiche pratique URLs have the pattern /particuliers/vosdroits/F followed by numbers
-		// Example: /particuliers/vosdroits/F16225
-		// Explicitly reject:
-		// - Category pages (N-prefix): /particuliers/vosdroits/N20020
-		// - Other paths that don't contain /F
-		if strings.Contains(href, "/N") || !strings.Contains(href, "/F") {
-			return
-		}
+			// Only process links that are fiche pratique pages (F-URLs)
+			// Fiche pratique URLs have the pattern /particuliers/vosdroits/F followed by numbers
+			// Example: /particuliers/vosdroits/F16225
+			// Explicitly reject:
+			// - Category pages (N-prefix): /particuliers/vosdroits/N20020
+			// - Other paths that don't contain /F
+			if strings.Contains(href, "/N") || !strings.Contains(href, "/F") {
+				return
+			}
 
-		// Additional validation: ensure it's specifically /vosdroits/F pattern
-		// This prevents matching other F-prefixed URLs
-		if !strings.Contains(href, "/vosdroits/F") {
-			return
-		}
+			// Additional validation: ensure it's specifically /vosdroits/F pattern
+			// This prevents matching other F-prefixed URLs
+			if !strings.Contains(href, "/vosdroits/F") {
+				return
+			}
 
-		// Get the title - extract from the tile text
-		title := strings.TrimSpace(e.Text)
-		if title == "" {
-			return
-		}
+			// Get the title - extract from the tile text
+			title := strings.TrimSpace(e.Text)
+			if title == "" {
+				return
+			}
 
-		// Make URL absolute
-		fullURL := e.Request.AbsoluteURL(href)
+			// Make URL absolute
+			fullURL := e.Request.AbsoluteURL(href)
 
-		// Check for duplicates
-		for _, event := range events {
-			if event.URL == fullURL {
-				return
+			// Check for duplicates
+			for _, event := range events {
+				if event.URL == fullURL {
+					return
+				}
 			}
-		}
 
-		events = append(events, LifeEvent{
-			Title:       title,
-			URL:         fullURL,
-			Description: fmt.Sprintf("Information and procedures for: %s", title),
+			events = append(events, LifeEvent{
+				Title:       title,
+				URL:         fullURL,
+				Description: fmt.Sprintf("Information and procedures for: %s", title),
+			})
 		})
 	})
-
-	// Handle errors
-	scraper.OnError(func(r *colly.Response, err error) {
-		select {
-		case errorChan <- fmt.Errorf("failed to fetch life events: %w", err):
-		default:
-		}
-	})
-
-	// Visit the "comment faire si" page
-	if err := scraper.Visit(c.baseURL + "/particuliers/vosdroits/comment-faire-si"); err != nil {
-		return nil, fmt.Errorf("failed to visit comment-faire-si page: %w", err)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
 	}
 
-	// Wait for scraping to complete
-	scraper.Wait()
-
-	// Check for errors
-	select {
-	case err := <-errorChan:
-		if len(events) == 0 {
-			return nil, err
+	if err != nil && len(events) == 0 {
+		if stale, ok := fromStaleCache[[]LifeEvent](c.fetcher.cache, cacheKey, err); ok {
+			return stale, nil
 		}
-		// If we got some results, return them despite the error
-	default:
+		return nil, fmt.Errorf("failed to fetch life events: %w", err)
 	}
+	// If we got some results, return them despite the error
 
 	if len(events) == 0 {
-		return nil, fmt.Errorf("no life events found")
+		return nil, errorf(ErrParseFailure, "no life events found")
 	}
 
+	c.fetcher.cache.set(opList, cacheKey, events)
 	return events, nil
 }
 
@@ -579,6 +568,23 @@ func (c *Client) ListLifeEvents(ctx context.Context) ([]LifeEvent, error) {
 type LifeEventSection struct {
 	Title   string
 	Content string
+	// Markdown is Content with lists, emphasis, links and tables kept as
+	// Markdown.
+	Markdown string
+	// Tables holds the tables of the section, which Content leaves out.
+	Tables []Table
+}
+
+// lifeEventMarkdownSelector matches the blocks rendered in
+// LifeEventSection.Markdown.
+const lifeEventMarkdownSelector = "div.sp-chapter-content h3, div.sp-chapter-content h4, div.sp-chapter-content p[data-test='contenu-texte'], div.sp-chapter-content ul.sp-item-list, div.sp-chapter-content div.fr-highlight, div.sp-chapter-content table"
+
+// isLifeEventText reports whether text is life event content rather than
+// scripts, subscription or feedback boilerplate.
+func isLifeEventText(text string) bool {
+	return !strings.Contains(text, "javascript") &&
+		!strings.Contains(text, "Abonnement") &&
+		!strings.Contains(text, "Cette page vous a-t-elle")
 }
 
 // LifeEventDetails represents detailed information about a life event.
@@ -596,172 +602,90 @@ func (c *Client) GetLifeEventDetails(ctx context.Context, eventURL string) (*Lif
 		return nil, err
 	}
 
-	// Validate URL
-	if eventURL == "" {
-		return nil, fmt.Errorf("URL cannot be empty")
-	}
-
-	parsedURL, err := url.Parse(eventURL)
+	// Validate URL and domain
+	eventURL, parsedURL, err := c.resolve(eventURL)
 	if err != nil {
-		return nil, fmt.Errorf("invalid URL: %w", err)
-	}
-
-	// Ensure it's a service-public.gouv.fr URL
-	if parsedURL.Host == "" {
-		eventURL = c.baseURL + eventURL
-		parsedURL, err = url.Parse(eventURL)
-		if err != nil {
-			return nil, fmt.Errorf("invalid URL after making absolute: %w", err)
-		}
-	}
-
-	// Validate domain
-	host := strings.ToLower(parsedURL.Host)
-	validDomains := []string{
-		"service-public.gouv.fr",
-		"www.service-public.gouv.fr",
-		"service-public.fr",
-		"www.service-public.fr",
-	}
-
-	domainValid := false
-	for _, validDomain := range validDomains {
-		if host == validDomain {
-			domainValid = true
-			break
-		}
-	}
-
-	if !domainValid {
-		return nil, fmt.Errorf("URL must be from service-public.gouv.fr domain, got: %s", parsedURL.Host)
+		return nil, err
 	}
 
 	// Validate that this is a fiche pratique page (F-URL), not a category page (N-prefix)
 	// Life event fiche pages: /particuliers/vosdroits/F16225
 	// Category pages: /particuliers/vosdroits/N19808
 	if strings.Contains(parsedURL.Path, "/N") {
-		return nil, fmt.Errorf("URL must be a life event page (F-prefix), not a category page (N-prefix). Got: %s", eventURL)
+		return nil, errorf(ErrInvalidInput, "URL must be a life event page (F-prefix), not a category page (N-prefix). Got: %s", eventURL)
 	}
 	if !strings.Contains(parsedURL.Path, "/vosdroits/F") {
-		return nil, fmt.Errorf("URL must be a life event fiche pratique page (/vosdroits/F...). Got: %s", eventURL)
+		return nil, errorf(ErrInvalidInput, "URL must be a life event fiche pratique page (/vosdroits/F...). Got: %s", eventURL)
+	}
+
+	cacheKey := "life-event:" + eventURL
+	if cached, ok := fromCache[*LifeEventDetails](c.fetcher.cache, opArticle, cacheKey); ok {
+		return cached, nil
 	}
 
 	var details LifeEventDetails
 	details.URL = eventURL
-	errorChan := make(chan error, 1)
-
-	// Clone the collector
-	scraper := c.collector.Clone()
-
-	// Allow URL revisits to prevent "already visited" errors on repeated calls
-	scraper.AllowURLRevisit = true
-
-	// Set up context cancellation
-	done := make(chan struct{})
-	defer close(done)
 
-	go func() {
-		select {
-		case <-ctx.Done():
-			scraper = nil
-		case <-done:
-		}
-	}()
-
-	// Extract title
-	scraper.OnHTML("h1, h1.fr-h1", func(e *colly.HTMLElement) {
-		if details.Title == "" {
-			details.Title = strings.TrimSpace(e.Text)
-		}
-	})
+	err = c.fetcher.visit(ctx, eventURL, func(scraper *colly.Collector) {
+		// Extract title
+		scraper.OnHTML("h1, h1.fr-h1", func(e *colly.HTMLElement) {
+			if details.Title == "" {
+				details.Title = strings.TrimSpace(e.Text)
+			}
+		})
 
-	// Extract introduction
-	scraper.OnHTML("div#intro p, p.fr-text--lg", func(e *colly.HTMLElement) {
-		text := strings.TrimSpace(e.Text)
-		if text != "" && details.Introduction == "" {
-			details.Introduction = text
-		}
-	})
+		// Extract introduction
+		scraper.OnHTML("div#intro p, p.fr-text--lg", func(e *colly.HTMLElement) {
+			text := strings.TrimSpace(e.Text)
+			if text != "" && details.Introduction == "" {
+				details.Introduction = text
+			}
+		})
 
-	// Extract sections - using the accordion structure
-	// Each section is a fr-accordion with an accordion__btn containing the title
-	scraper.OnHTML("section.fr-accordion[data-test='div-chapter']", func(e *colly.HTMLElement) {
-		// Get section title from the accordion button
-		sectionTitle := strings.TrimSpace(e.ChildText(".sp-accordion-chapter-btn-text"))
-		if sectionTitle == "" {
-			return
-		}
+		// Extract sections - using the accordion structure
+		// Each section is a fr-accordion with an accordion__btn containing the title
+		scraper.OnHTML("section.fr-accordion[data-test='div-chapter']", func(e *colly.HTMLElement) {
+			// Get section title from the accordion button
+			sectionTitle := strings.TrimSpace(e.ChildText(".sp-accordion-chapter-btn-text"))
+			if sectionTitle == "" {
+				return
+			}
 
-		// Skip questionnaire sections
-		if strings.Contains(sectionTitle, "Votre situation") {
-			return
-		}
+			// Skip questionnaire sections
+			if strings.Contains(sectionTitle, "Votre situation") {
+				return
+			}
 
-		// Extract content from the collapse div
-		var contentParts []string
+			// Extract content from the collapse div
+			var contentParts []string
 
-		// Get all paragraphs and lists from the section content
-		e.ForEach("div.sp-chapter-content p[data-test='contenu-texte'], div.sp-chapter-content ul.sp-item-list li, div.sp-chapter-content div.fr-highlight p", func(_ int, elem *colly.HTMLElement) {
-			text := strings.TrimSpace(elem.Text)
-			if text != "" && len(text) > 10 {
+			// Get all paragraphs and lists from the section content
+			e.ForEach("div.sp-chapter-content p[data-test='contenu-texte'], div.sp-chapter-content ul.sp-item-list li, div.sp-chapter-content div.fr-highlight p", func(_ int, elem *colly.HTMLElement) {
+				text := strings.TrimSpace(elem.Text)
 				// Filter out unwanted content
-				if !strings.Contains(text, "javascript") &&
-					!strings.Contains(text, "Abonnement") &&
-					!strings.Contains(text, "Cette page vous a-t-elle") {
+				if text != "" && len(text) > 10 && isLifeEventText(text) {
 					contentParts = append(contentParts, text)
 				}
-			}
-		})
-
-		// Only add section if it has content
-		if len(contentParts) > 0 {
-			details.Sections = append(details.Sections, LifeEventSection{
-				Title:   sectionTitle,
-				Content: strings.Join(contentParts, "\n\n"),
 			})
-		}
-	})
 
-	// Handle HTTP responses
-	scraper.OnResponse(func(r *colly.Response) {
-		if r.StatusCode == 404 {
-			select {
-			case errorChan <- fmt.Errorf("life event not found (404) at URL: %s", r.Request.URL):
-			default:
-			}
-		} else if r.StatusCode >= 400 {
-			select {
-			case errorChan <- fmt.Errorf("HTTP error %d at URL: %s", r.StatusCode, r.Request.URL):
-			default:
+			tables := parseTables(e, "div.sp-chapter-content table")
+
+			// Only add section if it has content
+			if len(contentParts) > 0 || len(tables) > 0 {
+				details.Sections = append(details.Sections, LifeEventSection{
+					Title:    sectionTitle,
+					Content:  strings.Join(contentParts, "\n\n"),
+					Markdown: renderMarkdown(e, lifeEventMarkdownSelector, isLifeEventText),
+					Tables:   tables,
+				})
 			}
-		}
+		})
 	})
-
-	// Handle errors
-	scraper.OnError(func(r *colly.Response, err error) {
-		statusMsg := ""
-		if r != nil {
-			statusMsg = fmt.Sprintf(" (HTTP %d)", r.StatusCode)
-		}
-		select {
-		case errorChan <- fmt.Errorf("failed to fetch life event%s: %w", statusMsg, err):
-		default:
+	if err != nil {
+		if stale, ok := fromStaleCache[*LifeEventDetails](c.fetcher.cache, cacheKey, err); ok {
+			return stale, nil
 		}
-	})
-
-	// Visit the life event page
-	if err := scraper.Visit(eventURL); err != nil {
-		return nil, fmt.Errorf("failed to visit life event page: %w", err)
-	}
-
-	// Wait for scraping to complete
-	scraper.Wait()
-
-	// Check for errors
-	select {
-	case err := <-errorChan:
 		return nil, err
-	default:
 	}
 
 	// Validate that we got content
@@ -769,8 +693,9 @@ func (c *Client) GetLifeEventDetails(ctx context.Context, eventURL string) (*Lif
 		details.Title = "Life Event from service-public.gouv.fr"
 	}
 	if details.Introduction == "" && len(details.Sections) == 0 {
-		return nil, fmt.Errorf("no content found at URL: %s", eventURL)
+		return nil, errorf(ErrParseFailure, "no content found at URL: %s", eventURL)
 	}
 
+	c.fetcher.cache.set(opArticle, cacheKey, &details)
 	return &details, nil
 }
@@ -14,64 +14,60 @@ func TestNew(t *testing.T) {
 		t.Fatal("New() returned nil")
 	}
 
-	if client.collector == nil {
-		t.Error("collector should not be nil")
+	if client.fetcher == nil {
+		t.Error("fetcher should not be nil")
 	}
 
-	if client.timeout != timeout {
-		t.Errorf("timeout = %v, want %v", client.timeout, timeout)
-	}
-
-	if client.baseURL == "" {
+	if client.fetcher.site.baseURL == "" {
 		t.Error("baseURL should not be empty")
 	}
 }
 
 func TestSearchProcedures(t *testing.T) {
 	tests := []struct {
-		name    string
-		query   string
-		limit   int
-		wantErr bool
+		name  string
+		query string
+		limit int
+		want  int
 	}{
 		{
-			name:    "valid search",
-			query:   "carte d'identité",
-			limit:   10,
-			wantErr: false, // Should return fallback results
+			name:  "valid search",
+			query: "carte identité",
+			limit: 10,
+			want:  5,
+		},
+		{
+			name:  "no match",
+			query: "zzzz",
+			limit: 10,
+			want:  0, // No fake "no results" entry
 		},
 		{
-			name:    "empty query",
-			query:   "",
-			limit:   10,
-			wantErr: false, // Will use fallback search
+			name:  "empty query",
+			query: "",
+			limit: 10,
+			want:  0,
 		},
 		{
-			name:    "limit too high",
-			query:   "passeport",
-			limit:   200,
-			wantErr: false, // Will be clamped to 10
+			name:  "limit too high",
+			query: "carte identité",
+			limit: 200,
+			want:  5, // Clamped to 10
 		},
 	}
 
-	client := New(30 * time.Second)
+	client, _ := newFixtureClient(t)
 	ctx := context.Background()
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			results, err := client.SearchProcedures(ctx, tt.query, tt.limit)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("SearchProcedures() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-
-			if !tt.wantErr && results == nil {
-				t.Error("SearchProcedures() returned nil results")
+			if err != nil {
+				t.Fatalf("SearchProcedures() error = %v", err)
 			}
 
-			// Should always return at least fallback results
-			if len(results) == 0 {
-				t.Error("SearchProcedures() returned empty results, expected at least fallback")
+			if len(results) != tt.want {
+				t.Errorf("SearchProcedures() returned %d results, want %d", len(results), tt.want)
 			}
 		})
 	}
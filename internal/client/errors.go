@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/gocolly/colly/v2"
+)
+
+// Kinds of failure. Every error returned by the clients, other than context
+// cancellation, wraps one of them so callers can test it with errors.Is.
+var (
+	// ErrInvalidInput means the request itself is wrong, such as an empty URL
+	// or a fiche URL where a category is expected.
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrNotFound means the page does not exist on the website, or in the
+	// offline snapshot.
+	ErrNotFound = errors.New("not found")
+	// ErrForbiddenDomain means the URL, or a redirect, points outside the
+	// hosts the client may fetch.
+	ErrForbiddenDomain = errors.New("forbidden domain")
+	// ErrUpstreamUnavailable means the website could not be reached or
+	// answered with a server error.
+	ErrUpstreamUnavailable = errors.New("upstream unavailable")
+	// ErrRateLimited means the website asked to slow down (HTTP 429).
+	ErrRateLimited = errors.New("rate limited")
+	// ErrParseFailure means the page was fetched but the expected content
+	// could not be found in it, usually because the website layout changed.
+	ErrParseFailure = errors.New("parse failure")
+	// ErrTimeout means the website did not answer in time.
+	ErrTimeout = errors.New("timeout")
+	// ErrBinaryDocument means the URL points to a file, such as a PDF or an
+	// image, rather than to a web page.
+	ErrBinaryDocument = errors.New("binary document")
+	// ErrTooLarge means the response exceeded the size limit.
+	ErrTooLarge = errors.New("response too large")
+	// ErrDisallowed means the website's robots.txt does not allow fetching
+	// the page.
+	ErrDisallowed = errors.New("disallowed by robots.txt")
+)
+
+// Error is a failure of a given kind. Its message is that of Err; errors.Is
+// matches both Kind and the errors wrapped by Err.
+type Error struct {
+	// Kind is one of the Err* sentinel errors.
+	Kind error
+	// StatusCode is the HTTP status that caused the failure, if any.
+	StatusCode int
+	// RetryAfter is the delay the website asked to wait before trying
+	// again, if any.
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns both the kind and the cause of the failure.
+func (e *Error) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// errorf returns an Error of the given kind whose cause is formatted as
+// with fmt.Errorf.
+func errorf(kind error, format string, args ...any) error {
+	return &Error{Kind: kind, Err: fmt.Errorf(format, args...)}
+}
+
+// Retryable reports whether the operation that failed with err may succeed
+// if tried again later.
+func Retryable(err error) bool {
+	return errors.Is(err, ErrUpstreamUnavailable) ||
+		errors.Is(err, ErrRateLimited) ||
+		errors.Is(err, ErrTimeout) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
+
+// statusError classifies an HTTP error status returned for pageURL with the
+// response header.
+func statusError(statusCode int, pageURL string, header http.Header) error {
+	err := fmt.Errorf("HTTP error %d at URL: %s", statusCode, pageURL)
+
+	var kind error
+	switch statusCode {
+	case http.StatusNotFound, http.StatusGone:
+		kind = ErrNotFound
+		err = fmt.Errorf("page not found (%d) at URL: %s", statusCode, pageURL)
+	case http.StatusTooManyRequests:
+		kind = ErrRateLimited
+	case http.StatusRequestTimeout, http.StatusGatewayTimeout:
+		kind = ErrTimeout
+	default:
+		// Other refusals, such as 403 from a firewall, are on the website's side
+		kind = ErrUpstreamUnavailable
+	}
+	retryAfter, _ := parseRetryAfter(header.Get("Retry-After"), time.Now())
+	return &Error{Kind: kind, StatusCode: statusCode, RetryAfter: retryAfter, Err: err}
+}
+
+// requestError classifies a failure to get any response at all.
+func requestError(err error) error {
+	var netErr net.Error
+	switch {
+	case errors.As(err, new(*Error)):
+		// Already classified, e.g. by a transport
+		return fmt.Errorf("request failed: %w", err)
+	case errors.Is(err, colly.ErrForbiddenDomain):
+		return errorf(ErrForbiddenDomain, "request failed: %w", err)
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return errorf(ErrTimeout, "request failed: %w", err)
+	}
+	return errorf(ErrUpstreamUnavailable, "request failed: %w", err)
+}
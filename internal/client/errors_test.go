@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gocolly/colly/v2"
+)
+
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		status    int
+		kind      error
+		retryable bool
+	}{
+		{http.StatusNotFound, ErrNotFound, false},
+		{http.StatusGone, ErrNotFound, false},
+		{http.StatusTooManyRequests, ErrRateLimited, true},
+		{http.StatusGatewayTimeout, ErrTimeout, true},
+		{http.StatusServiceUnavailable, ErrUpstreamUnavailable, true},
+		{http.StatusForbidden, ErrUpstreamUnavailable, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			err := statusError(tt.status, "https://example.com/page", nil)
+			if !errors.Is(err, tt.kind) {
+				t.Errorf("statusError(%d) = %v, want %v", tt.status, err, tt.kind)
+			}
+			if got := Retryable(err); got != tt.retryable {
+				t.Errorf("Retryable(statusError(%d)) = %v, want %v", tt.status, got, tt.retryable)
+			}
+
+			var e *Error
+			if !errors.As(err, &e) || e.StatusCode != tt.status {
+				t.Errorf("statusError(%d) StatusCode = %+v", tt.status, e)
+			}
+		})
+	}
+}
+
+func TestRequestError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		kind error
+	}{
+		{"refused", errors.New("connection refused"), ErrUpstreamUnavailable},
+		{"deadline", fmt.Errorf("get: %w", context.DeadlineExceeded), ErrTimeout},
+		{"redirect", colly.ErrForbiddenDomain, ErrForbiddenDomain},
+		{"classified", errorf(ErrNotFound, "not in snapshot"), ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := requestError(tt.err); !errors.Is(err, tt.kind) {
+				t.Errorf("requestError(%v) = %v, want %v", tt.err, err, tt.kind)
+			}
+		})
+	}
+}
+
+func TestErrorKeepsCause(t *testing.T) {
+	err := errorf(ErrParseFailure, "no content: %w", context.Canceled)
+
+	if err.Error() != "no content: context canceled" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+	if !errors.Is(err, ErrParseFailure) || !errors.Is(err, context.Canceled) {
+		t.Errorf("errors.Is should match both the kind and the cause of %v", err)
+	}
+	if Retryable(err) {
+		t.Error("parse failures should not be retryable")
+	}
+}
+
+func TestFetchErrorKinds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/particuliers/vosdroits/F429":
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case "/particuliers/vosdroits/F503":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case "/particuliers/vosdroits/Fslow":
+			select {
+			case <-time.After(2 * time.Second):
+			case <-r.Context().Done():
+			}
+		default:
+			// A page without any article content
+			_, _ = w.Write([]byte("<html><body><p>Maintenance</p></body></html>"))
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		path string
+		kind error
+	}{
+		{"/particuliers/vosdroits/F429", ErrRateLimited},
+		{"/particuliers/vosdroits/F503", ErrUpstreamUnavailable},
+		{"/particuliers/vosdroits/Fslow", ErrTimeout},
+		{"/particuliers/vosdroits/F1", ErrParseFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			// A client per page keeps the rate limit delay out of the timeout
+			c := New(500*time.Millisecond, WithBaseURL(server.URL), WithRetry(RetryConfig{MaxAttempts: 1}), WithCrawl(CrawlConfig{IgnoreRobots: true}))
+			_, err := c.GetArticle(context.Background(), server.URL+tt.path)
+			if !errors.Is(err, tt.kind) {
+				t.Errorf("GetArticle(%s) error = %v, want %v", tt.path, err, tt.kind)
+			}
+			var e *Error
+			if tt.kind == ErrRateLimited && (!errors.As(err, &e) || e.RetryAfter != 2*time.Minute) {
+				t.Errorf("GetArticle(%s) RetryAfter = %+v, want 2m", tt.path, e)
+			}
+		})
+	}
+}
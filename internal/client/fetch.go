@@ -0,0 +1,308 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gocolly/colly/v2"
+)
+
+// site describes a government website scraped by this package.
+type site struct {
+	// name is the canonical domain, used in error messages.
+	name string
+	// baseURL is prepended to relative URLs and used to build page URLs.
+	baseURL string
+	// hosts lists every host name that may be fetched for this site.
+	hosts []string
+}
+
+// allowsHost reports whether host (without port) belongs to the site.
+func (s site) allowsHost(host string) bool {
+	host = canonicalHost(host)
+	for _, h := range s.hosts {
+		if host == h {
+			return true
+		}
+	}
+	return false
+}
+
+// fetcher runs the scraping pipeline shared by every client: it validates
+// URLs against the site, clones the collector, binds the request context,
+// collects errors and waits for the visit to complete. Callers only register
+// the HTML handlers that extract their data.
+type fetcher struct {
+	site      site
+	policy    urlPolicy
+	collector *colly.Collector
+	cache     *cache
+	snapshot  *Snapshot
+	// breaker is nil when the circuit breaker is disabled.
+	breaker *breakerTransport
+}
+
+// newFetcher creates a fetcher for s with the specified timeout, after
+// applying any site overrides from o.
+func newFetcher(s site, timeout time.Duration, o *options) *fetcher {
+	s = o.apply(s)
+
+	c := colly.NewCollector(
+		colly.AllowedDomains(s.hosts...),
+		colly.UserAgent(o.crawlConfig().UserAgent),
+		colly.Async(false),
+		// Clones share the parent's redirect check, which must not refuse
+		// pages fetched before
+		colly.AllowURLRevisit(),
+		// The transport enforces LimitConfig, failing instead of truncating
+		colly.MaxBodySize(0),
+	)
+
+	// Set timeout
+	c.SetRequestTimeout(timeout)
+
+	policy := newURLPolicy(s)
+	transport, breaker := newTransport(policy, o)
+	c.WithTransport(transport)
+
+	return &fetcher{
+		site:      s,
+		policy:    policy,
+		collector: c,
+		cache:     newCache(s.name, o.cache),
+		snapshot:  o.snapshot,
+		breaker:   breaker,
+	}
+}
+
+// newTransport builds the HTTP transport stack described by o, sending only
+// the requests that policy allows, and returns its circuit breaker, if any.
+func newTransport(policy urlPolicy, o *options) (http.RoundTripper, *breakerTransport) {
+	// Offline mode never reaches the network
+	if o.snapshot != nil {
+		return &snapshotTransport{snapshot: o.snapshot}, nil
+	}
+
+	// Configure rate limiting to be respectful. The limit is enforced in the
+	// transport so that waiting for a slot honours context cancellation.
+	crawl := o.crawlConfig()
+	polite := newPoliteTransport(nil, crawl.Parallelism, crawl.Delay)
+
+	// Every request reaching the network, including redirects, retries and
+	// robots.txt, is checked against the URL policy
+	var transport http.RoundTripper = &policyTransport{base: polite, policy: policy}
+
+	// Bound what is downloaded before anything reads it
+	limits := DefaultLimitConfig
+	if o.limits != nil {
+		limits = *o.limits
+	}
+	transport = &limitTransport{base: transport, cfg: limits}
+
+	// Retries go through the rate limit too
+	retry := DefaultRetryConfig
+	if o.retry != nil {
+		retry = *o.retry
+	}
+	if retry.MaxAttempts > 1 {
+		transport = newRetryTransport(transport, retry)
+	}
+
+	// Fail fast while a host is down, once retries have been exhausted
+	breakerCfg := DefaultBreakerConfig
+	if o.breaker != nil {
+		breakerCfg = *o.breaker
+	}
+	var breaker *breakerTransport
+	if breakerCfg.Threshold > 0 {
+		breaker = newBreakerTransport(transport, breakerCfg)
+		transport = breaker
+	}
+
+	// Pages disallowed by robots.txt are never requested
+	if !crawl.IgnoreRobots {
+		transport = newRobotsTransport(transport, crawl.UserAgent, polite.setCrawlDelay)
+	}
+
+	// Pages served from the disk cache skip the rate limit entirely
+	if o.pageCacheDir != "" {
+		transport = newPageCacheTransport(transport, o.pageCacheDir, o.pageCacheTTL)
+	}
+
+	if o.recorder != nil {
+		transport = &recordingTransport{base: transport, snapshot: o.recorder}
+	}
+
+	return transport, breaker
+}
+
+// callerKey is the context key of the context a request was made with,
+// before the HTTP client adds its own timeout.
+type callerKey struct{}
+
+// withCaller records ctx as the caller context of the requests made with it.
+func withCaller(ctx context.Context) context.Context {
+	return context.WithValue(ctx, callerKey{}, ctx)
+}
+
+// callerDone reports whether the caller of req gave up on it, by cancelling
+// it or through its own deadline, rather than the request timing out. It is
+// false for requests made without withCaller.
+func callerDone(req *http.Request) bool {
+	caller, ok := req.Context().Value(callerKey{}).(context.Context)
+	return ok && caller.Err() != nil
+}
+
+// snapshotDate returns when the snapshot being served was taken, or the zero
+// time when pages are fetched live.
+func (f *fetcher) snapshotDate() time.Time {
+	if f.snapshot == nil {
+		return time.Time{}
+	}
+	return f.snapshot.CreatedAt
+}
+
+// owns reports whether rawURL is an absolute URL on one of the site's hosts.
+func (f *fetcher) owns(rawURL string) bool {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	return err == nil && u.Host != "" && f.site.allowsHost(u.Hostname())
+}
+
+// degraded reports whether the host of pageURL is failing and requests to
+// it are not being sent.
+func (f *fetcher) degraded(pageURL string) bool {
+	u, err := url.Parse(pageURL)
+	if err != nil {
+		return false
+	}
+	return f.breaker.open(u.Host)
+}
+
+// resolve validates rawURL, makes it absolute against the site's base URL,
+// checks that it points to one of the site's hosts and normalizes it
+// according to the URL policy.
+func (f *fetcher) resolve(rawURL string) (string, *url.URL, error) {
+	if rawURL == "" {
+		return "", nil, errorf(ErrInvalidInput, "URL cannot be empty")
+	}
+
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return "", nil, errorf(ErrInvalidInput, "invalid URL: %w", err)
+	}
+
+	// Handle relative URLs by making them absolute
+	if parsedURL.Host == "" {
+		if !strings.HasPrefix(rawURL, "/") {
+			return "", nil, errorf(ErrInvalidInput, "invalid URL: %s", rawURL)
+		}
+		rawURL = f.site.baseURL + rawURL
+		parsedURL, err = url.Parse(rawURL)
+		if err != nil {
+			return "", nil, errorf(ErrInvalidInput, "invalid URL after making absolute: %w", err)
+		}
+	}
+
+	if !f.site.allowsHost(parsedURL.Hostname()) {
+		return "", nil, errorf(ErrForbiddenDomain, "URL must be from %s domain, got: %s", f.site.name, parsedURL.Host)
+	}
+
+	parsedURL, err = f.policy.normalize(parsedURL)
+	if err != nil {
+		return "", nil, err
+	}
+	return parsedURL.String(), parsedURL, nil
+}
+
+// visit fetches pageURL and dispatches the response to the handlers that
+// setup registers on the cloned collector. It returns ctx.Err() if the
+// context ends before the page has been processed, and an ErrBinaryDocument
+// error if pageURL is a PDF document.
+func (f *fetcher) visit(ctx context.Context, pageURL string, setup func(scraper *colly.Collector)) error {
+	return f.visitDocument(ctx, pageURL, setup, nil)
+}
+
+// visitDocument is like visit, but routes PDF documents to onPDF instead of
+// failing. HTML pages still go to the handlers registered by setup.
+func (f *fetcher) visitDocument(ctx context.Context, pageURL string, setup func(scraper *colly.Collector), onPDF func(r *colly.Response) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	errorChan := make(chan error, 1)
+
+	// Clone the collector to avoid conflicts between concurrent calls
+	scraper := f.collector.Clone()
+
+	// Allow URL revisits to prevent "already visited" errors on repeated calls
+	scraper.AllowURLRevisit = true
+
+	// Bind the request to ctx so cancellation aborts the in-flight fetch
+	scraper.Context = withCaller(ctx)
+
+	setup(scraper)
+
+	report := func(err error) {
+		select {
+		case errorChan <- err:
+		default:
+		}
+	}
+
+	// PDF documents never reach the HTML handlers
+	scraper.OnResponse(func(r *colly.Response) {
+		if !isPDF(r) {
+			return
+		}
+		if onPDF == nil {
+			report(errorf(ErrBinaryDocument, "%s is a PDF document, not a web page", r.Request.URL))
+			return
+		}
+		if err := onPDF(r); err != nil {
+			report(err)
+		}
+	})
+
+	// Handle errors, including HTTP error statuses
+	scraper.OnError(func(r *colly.Response, err error) {
+		if r != nil && r.StatusCode >= 400 {
+			var header http.Header
+			if r.Headers != nil {
+				header = *r.Headers
+			}
+			err = statusError(r.StatusCode, r.Request.URL.String(), header)
+		} else {
+			err = requestError(err)
+		}
+		report(err)
+	})
+
+	visitErr := scraper.Visit(pageURL)
+
+	// Wait for scraping to complete
+	scraper.Wait()
+
+	// Report cancellation rather than a scraping failure
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	select {
+	case err := <-errorChan:
+		return err
+	default:
+	}
+
+	if visitErr != nil {
+		if errors.Is(visitErr, colly.ErrForbiddenDomain) {
+			return errorf(ErrForbiddenDomain, "failed to visit %s: %w", pageURL, visitErr)
+		}
+		return errorf(ErrUpstreamUnavailable, "failed to visit %s: %w", pageURL, visitErr)
+	}
+
+	return nil
+}
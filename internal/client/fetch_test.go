@@ -0,0 +1,90 @@
+package client
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFetcherResolve(t *testing.T) {
+	f := newFetcher(servicePublic, 5*time.Second, newOptions(nil))
+
+	tests := []struct {
+		name    string
+		url     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "absolute URL",
+			url:  "https://www.service-public.gouv.fr/particuliers/vosdroits/F1234",
+			want: "https://www.service-public.gouv.fr/particuliers/vosdroits/F1234",
+		},
+		{
+			name: "legacy domain",
+			url:  "https://service-public.fr/particuliers/vosdroits/F1234",
+			want: "https://service-public.fr/particuliers/vosdroits/F1234",
+		},
+		{
+			name: "Cerfa forms host",
+			url:  "https://www.formulaires.service-public.gouv.fr/gf/cerfa_12100.do",
+			want: "https://www.formulaires.service-public.gouv.fr/gf/cerfa_12100.do",
+		},
+		{
+			name: "upper case host",
+			url:  "https://WWW.Service-Public.gouv.fr/particuliers",
+			want: "https://www.service-public.gouv.fr/particuliers",
+		},
+		{
+			name: "plain http upgraded",
+			url:  "http://www.service-public.gouv.fr/particuliers",
+			want: "https://www.service-public.gouv.fr/particuliers",
+		},
+		{
+			name: "trailing dot and default port",
+			url:  "https://www.service-public.gouv.fr.:443/particuliers",
+			want: "https://www.service-public.gouv.fr/particuliers",
+		},
+		{
+			name: "dot segments and fragment",
+			url:  "https://www.service-public.gouv.fr/particuliers/../particuliers/./vosdroits/F1234?lang=fr#top",
+			want: "https://www.service-public.gouv.fr/particuliers/vosdroits/F1234?lang=fr",
+		},
+		{
+			name: "relative URL",
+			url:  "/particuliers/vosdroits/F1234",
+			want: "https://www.service-public.gouv.fr/particuliers/vosdroits/F1234",
+		},
+		{
+			name:    "empty URL",
+			url:     "",
+			wantErr: true,
+		},
+		{
+			name:    "not a URL",
+			url:     "not-a-url",
+			wantErr: true,
+		},
+		{
+			name:    "wrong domain",
+			url:     "https://www.impots.gouv.fr/particulier",
+			wantErr: true,
+		},
+		{
+			name:    "lookalike domain",
+			url:     "https://service-public.gouv.fr.example.com/",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := f.resolve(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("resolve() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
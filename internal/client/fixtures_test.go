@@ -0,0 +1,526 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newFixtureServer serves saved pages from testdata. Pages are keyed by
+// request URI (path and query) or, failing that, by path alone.
+func newFixtureServer(t *testing.T, pages map[string]string) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, ok := pages[r.URL.RequestURI()]
+		if !ok {
+			file, ok = pages[r.URL.Path]
+		}
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		data, err := os.ReadFile(filepath.Join("testdata", file))
+		if err != nil {
+			t.Errorf("failed to read fixture %s: %v", file, err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", mime.TypeByExtension(filepath.Ext(file)))
+		_, _ = w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// servicePublicPages maps service-public.gouv.fr paths to fixtures.
+var servicePublicPages = map[string]string{
+	"/particuliers/recherche?keyword=carte+identit%C3%A9":        "service-public/search.html",
+	"/particuliers/recherche?keyword=carte+identit%C3%A9&page=2": "service-public/search-page2.html",
+	"/particuliers/recherche?keyword=redesign":                   "service-public/search-redesigned.html",
+	"/particuliers/recherche?keyword=":                           "service-public/search-empty.html",
+	"/particuliers/recherche?keyword=zzzz":                       "service-public/search-empty.html",
+	"/particuliers/recherche?keyword=cerfa+12101":                "service-public/search.html",
+	"/particuliers": "service-public/particuliers.html",
+	"/particuliers/vosdroits/comment-faire-si": "service-public/comment-faire-si.html",
+	"/particuliers/vosdroits/F1342":            "service-public/F1342.html",
+	"/files/cerfa_12100-03.pdf":                "service-public/cerfa_12100-03.pdf",
+	"/particuliers/vosdroits/F16225":           "service-public/F16225.html",
+	"/particuliers/vosdroits/N19803":           "service-public/N19803.html",
+	"/particuliers/vosdroits/N358":             "service-public/N358.html",
+}
+
+// entreprendrePages maps entreprendre.service-public.gouv.fr and
+// associations pages to fixtures. They are served alongside
+// servicePublicPages by newFixtureClient.
+var entreprendrePages = map[string]string{
+	"/":                                   "entreprendre/home.html",
+	"/recherche?keyword=micro-entreprise": "entreprendre/search.html",
+	"/vosdroits/F23282":                   "entreprendre/F23282.html",
+	"/associations":                       "associations/associations.html",
+}
+
+// impotsPages maps impots.gouv.fr paths to fixtures.
+var impotsPages = map[string]string{
+	"/recherche/formulaire+2042": "impots/search.html",
+	"/recherche/formulaire%202042?origin%5B0%5D=impots&search_filter=Filtrer&page=1": "impots/search-page2.html",
+	"/recherche/":     "impots/search-empty.html",
+	"/recherche/zzzz": "impots/search-empty.html",
+	"/particulier":    "impots/particulier.html",
+	"/sites/default/files/formulaires/2042/2025/notice-2042.pdf": "impots/notice-2042.pdf",
+	"/formulaire/2042/declaration-des-revenus":                   "impots/declaration-des-revenus.html",
+}
+
+// fixtureCrawl checks robots.txt like the live clients, without the rate
+// limit delay a local server does not need.
+var fixtureCrawl = WithCrawl(CrawlConfig{Parallelism: 1})
+
+func newFixtureClient(t *testing.T) (*Client, *httptest.Server) {
+	t.Helper()
+	pages := make(map[string]string, len(servicePublicPages)+len(entreprendrePages))
+	maps.Copy(pages, servicePublicPages)
+	maps.Copy(pages, entreprendrePages)
+
+	server := newFixtureServer(t, pages)
+	return New(5*time.Second, WithBaseURL(server.URL), fixtureCrawl), server
+}
+
+func newFixtureImpotsClient(t *testing.T) (*ImpotsClient, *httptest.Server) {
+	t.Helper()
+	server := newFixtureServer(t, impotsPages)
+	return NewImpotsClient(5*time.Second, WithBaseURL(server.URL), fixtureCrawl), server
+}
+
+func TestSearchProceduresFixture(t *testing.T) {
+	c, server := newFixtureClient(t)
+	ctx := context.Background()
+
+	results, err := c.SearchProcedures(ctx, "carte identité", 10)
+	if err != nil {
+		t.Fatalf("SearchProcedures() error = %v", err)
+	}
+
+	// The second page of results is followed to fill the limit
+	if len(results) != 5 {
+		t.Fatalf("SearchProcedures() returned %d results, want 5: %+v", len(results), results)
+	}
+
+	first := results[0]
+	if first.Title != "Carte d'identité d'un majeur : première demande" {
+		t.Errorf("first title = %q", first.Title)
+	}
+	if first.URL != server.URL+"/particuliers/vosdroits/F1342" {
+		t.Errorf("first URL = %q", first.URL)
+	}
+	if first.Description == "" {
+		t.Error("first result should have a description")
+	}
+	if results[2].Title != "Passeport" {
+		t.Errorf("title without nested span = %q, want Passeport", results[2].Title)
+	}
+	if results[4].Title != "Carte d'identité d'un mineur" {
+		t.Errorf("last title = %q, want result from page 2", results[4].Title)
+	}
+
+	limited, err := c.SearchProcedures(ctx, "carte identité", 2)
+	if err != nil {
+		t.Fatalf("SearchProcedures() with limit error = %v", err)
+	}
+	if len(limited) != 2 {
+		t.Errorf("SearchProcedures() with limit 2 returned %d results", len(limited))
+	}
+}
+
+func TestSearchProceduresFixtureNoResults(t *testing.T) {
+	c, _ := newFixtureClient(t)
+
+	results, err := c.SearchProcedures(context.Background(), "zzzz", 10)
+	if err != nil {
+		t.Fatalf("SearchProcedures() error = %v", err)
+	}
+	if len(results) != 0 {
+		t.Errorf("SearchProcedures() = %+v, want no results", results)
+	}
+
+	page, err := c.SearchProceduresPage(context.Background(), "zzzz", 10, "")
+	if err != nil {
+		t.Fatalf("SearchProceduresPage() error = %v", err)
+	}
+	if page.EmptyReason != EmptyNoMatch {
+		t.Errorf("EmptyReason = %q, want %q", page.EmptyReason, EmptyNoMatch)
+	}
+	if want := []string{"pizza", "jazz"}; !slices.Equal(page.Suggestions, want) {
+		t.Errorf("Suggestions = %q, want %q", page.Suggestions, want)
+	}
+}
+
+func TestSearchProceduresFixtureEmptyReasons(t *testing.T) {
+	c, _ := newFixtureClient(t)
+	ctx := context.Background()
+
+	// A page without results nor a "no results" message cannot be parsed
+	page, err := c.SearchProceduresPage(ctx, "redesign", 10, "")
+	if err != nil {
+		t.Fatalf("SearchProceduresPage() error = %v", err)
+	}
+	if len(page.Results) != 0 || page.EmptyReason != EmptyParseFailure {
+		t.Errorf("redesigned page = %+v, want %q", page, EmptyParseFailure)
+	}
+
+	// The fixture server has no page for this query
+	page, err = c.SearchProceduresPage(ctx, "unknown", 10, "")
+	if err != nil {
+		t.Fatalf("SearchProceduresPage() error = %v", err)
+	}
+	if page.EmptyReason != EmptySiteError || page.Err == nil {
+		t.Errorf("missing page = %+v, want %q with error", page, EmptySiteError)
+	}
+	if _, err := c.SearchProcedures(ctx, "unknown", 10); err == nil {
+		t.Error("SearchProcedures() on a failing site error = nil, want error")
+	}
+}
+
+func TestGetArticleFixture(t *testing.T) {
+	c, server := newFixtureClient(t)
+	ctx := context.Background()
+
+	article, err := c.GetArticle(ctx, server.URL+"/particuliers/vosdroits/F1342")
+	if err != nil {
+		t.Fatalf("GetArticle() error = %v", err)
+	}
+
+	if article.Title != "Carte d'identité d'un majeur : première demande" {
+		t.Errorf("Title = %q", article.Title)
+	}
+	for _, want := range []string{
+		"La carte d'identité est un document officiel",
+		"Où faire la demande ?",
+		"Prendre rendez-vous",
+		"gratuite pour une première demande",
+	} {
+		if !strings.Contains(article.Content, want) {
+			t.Errorf("Content missing %q", want)
+		}
+	}
+	if strings.Contains(article.Content, "Abonnement") {
+		t.Error("Content should not contain filtered text")
+	}
+
+	// Relative URLs are resolved against the base URL
+	relative, err := c.GetArticle(ctx, "/particuliers/vosdroits/F1342")
+	if err != nil {
+		t.Fatalf("GetArticle() with relative URL error = %v", err)
+	}
+	if relative.URL != server.URL+"/particuliers/vosdroits/F1342" {
+		t.Errorf("URL = %q", relative.URL)
+	}
+}
+
+func TestGetArticleFixtureSections(t *testing.T) {
+	c, _ := newFixtureClient(t)
+
+	article, err := c.GetArticle(context.Background(), "/particuliers/vosdroits/F1342")
+	if err != nil {
+		t.Fatalf("GetArticle() error = %v", err)
+	}
+
+	if len(article.Sections) != 2 {
+		t.Fatalf("got %d top-level sections, want 2: %+v", len(article.Sections), article.Sections)
+	}
+
+	where := article.Sections[0]
+	if where.Level != 2 || where.Title != "Où faire la demande ?" {
+		t.Errorf("first section = level %d %q", where.Level, where.Title)
+	}
+	if len(where.Paragraphs) != 1 || !strings.Contains(where.Paragraphs[0], "n'importe quelle mairie") {
+		t.Errorf("first section paragraphs = %q", where.Paragraphs)
+	}
+
+	if len(where.Sections) != 1 {
+		t.Fatalf("got %d subsections, want 1", len(where.Sections))
+	}
+	appointment := where.Sections[0]
+	if appointment.Level != 3 || appointment.Title != "Prendre rendez-vous" {
+		t.Errorf("subsection = level %d %q", appointment.Level, appointment.Title)
+	}
+	wantItems := []string{
+		"Photo d'identité de moins de 6 mois",
+		"Justificatif de domicile Facture d'électricité",
+	}
+	if len(appointment.Lists) != 1 || strings.Join(appointment.Lists[0], "|") != strings.Join(wantItems, "|") {
+		t.Errorf("subsection lists = %q, want [%q]", appointment.Lists, wantItems)
+	}
+
+	cost := article.Sections[1]
+	if cost.Title != "Coût" || len(cost.Paragraphs) != 1 || !strings.Contains(cost.Paragraphs[0], "gratuite") {
+		t.Errorf("second section = %+v", cost)
+	}
+	for _, section := range article.Sections {
+		for _, p := range section.Paragraphs {
+			if strings.Contains(p, "Abonnement") {
+				t.Errorf("section %q should not contain filtered text", section.Title)
+			}
+		}
+	}
+}
+
+func TestGetArticleFixtureNotFound(t *testing.T) {
+	c, server := newFixtureClient(t)
+
+	_, err := c.GetArticle(context.Background(), server.URL+"/particuliers/vosdroits/F0000")
+	if err == nil {
+		t.Fatal("GetArticle() should fail for a missing page")
+	}
+	if !strings.Contains(err.Error(), "404") || !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetArticle() error = %v, want 404 ErrNotFound", err)
+	}
+}
+
+func TestGetArticleFixtureRejectsOfficialHost(t *testing.T) {
+	c, _ := newFixtureClient(t)
+
+	// Only the fixture host is allowed once the base URL is overridden
+	_, err := c.GetArticle(context.Background(), "https://www.service-public.gouv.fr/particuliers/vosdroits/F1342")
+	if !errors.Is(err, ErrForbiddenDomain) {
+		t.Errorf("GetArticle() error = %v, want ErrForbiddenDomain for hosts outside the allowed list", err)
+	}
+}
+
+func TestGetArticleFixtureCancellation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	c := New(30*time.Second, WithBaseURL(server.URL))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := c.GetArticle(ctx, server.URL+"/particuliers/vosdroits/F1342")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("GetArticle() error = %v, want context.Canceled", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("GetArticle() took %v after cancellation", elapsed)
+	}
+}
+
+func TestListCategoriesFixture(t *testing.T) {
+	c, _ := newFixtureClient(t)
+
+	categories, err := c.ListCategories(context.Background())
+	if err != nil {
+		t.Fatalf("ListCategories() error = %v", err)
+	}
+
+	want := []string{"Papiers - Citoyenneté - Élections", "Famille - Scolarité", "Social - Santé"}
+	if len(categories) != len(want) {
+		t.Fatalf("ListCategories() returned %d categories, want %d: %+v", len(categories), len(want), categories)
+	}
+	for i, name := range want {
+		if categories[i].Name != name {
+			t.Errorf("category %d = %q, want %q", i, categories[i].Name, name)
+		}
+	}
+}
+
+func TestListCategoriesFixtureFallback(t *testing.T) {
+	server := newFixtureServer(t, nil)
+	c := New(5*time.Second, WithBaseURL(server.URL))
+
+	categories, err := c.ListCategories(context.Background())
+	if err != nil {
+		t.Fatalf("ListCategories() error = %v", err)
+	}
+	if len(categories) != len(c.getDefaultCategories()) {
+		t.Errorf("ListCategories() = %+v, want default categories", categories)
+	}
+}
+
+func TestListLifeEventsFixture(t *testing.T) {
+	c, server := newFixtureClient(t)
+
+	events, err := c.ListLifeEvents(context.Background())
+	if err != nil {
+		t.Fatalf("ListLifeEvents() error = %v", err)
+	}
+
+	if len(events) != 3 {
+		t.Fatalf("ListLifeEvents() returned %d events, want 3: %+v", len(events), events)
+	}
+	for _, event := range events {
+		if !strings.HasPrefix(event.URL, server.URL+"/particuliers/vosdroits/F") {
+			t.Errorf("event %q has non fiche URL %s", event.Title, event.URL)
+		}
+	}
+}
+
+func TestGetLifeEventDetailsFixture(t *testing.T) {
+	c, server := newFixtureClient(t)
+
+	details, err := c.GetLifeEventDetails(context.Background(), server.URL+"/particuliers/vosdroits/F16225")
+	if err != nil {
+		t.Fatalf("GetLifeEventDetails() error = %v", err)
+	}
+
+	if details.Title != "J'attends un enfant" {
+		t.Errorf("Title = %q", details.Title)
+	}
+	if !strings.HasPrefix(details.Introduction, "Vous attendez un enfant") {
+		t.Errorf("Introduction = %q", details.Introduction)
+	}
+	if len(details.Sections) != 2 {
+		t.Fatalf("got %d sections, want 2: %+v", len(details.Sections), details.Sections)
+	}
+	if details.Sections[0].Title != "Santé" || details.Sections[1].Title != "État civil" {
+		t.Errorf("section titles = %q, %q", details.Sections[0].Title, details.Sections[1].Title)
+	}
+	if strings.Contains(details.Sections[0].Content, "Court") {
+		t.Error("short list items should be filtered out")
+	}
+}
+
+func TestSearchImpotsFixture(t *testing.T) {
+	c, server := newFixtureImpotsClient(t)
+
+	results, err := c.SearchImpots(context.Background(), "formulaire 2042", 10)
+	if err != nil {
+		t.Fatalf("SearchImpots() error = %v", err)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("SearchImpots() returned %d results, want 3: %+v", len(results), results)
+	}
+
+	first := results[0]
+	if first.URL != server.URL+"/formulaire/2042/declaration-des-revenus" {
+		t.Errorf("URL = %q", first.URL)
+	}
+	if first.Type != "Formulaire" || first.Date != "16/04/2025" {
+		t.Errorf("Type = %q, Date = %q", first.Type, first.Date)
+	}
+	if first.Description == "" {
+		t.Error("first result should have a description")
+	}
+}
+
+func TestSearchImpotsFixtureNoResults(t *testing.T) {
+	c, _ := newFixtureImpotsClient(t)
+
+	page, err := c.SearchImpotsPage(context.Background(), "zzzz", 10, "")
+	if err != nil {
+		t.Fatalf("SearchImpotsPage() error = %v", err)
+	}
+	if len(page.Results) != 0 || page.EmptyReason != EmptyNoMatch {
+		t.Errorf("SearchImpotsPage() = %+v, want %q", page, EmptyNoMatch)
+	}
+	if want := []string{"zozo"}; !slices.Equal(page.Suggestions, want) {
+		t.Errorf("Suggestions = %q, want %q", page.Suggestions, want)
+	}
+}
+
+func TestGetImpotsArticleFixture(t *testing.T) {
+	c, server := newFixtureImpotsClient(t)
+
+	article, err := c.GetImpotsArticle(context.Background(), server.URL+"/formulaire/2042/declaration-des-revenus")
+	if err != nil {
+		t.Fatalf("GetImpotsArticle() error = %v", err)
+	}
+
+	if article.Title != "Formulaire 2042 : déclaration des revenus" {
+		t.Errorf("Title = %q", article.Title)
+	}
+	if article.Description != "Déclaration des revenus 2042" {
+		t.Errorf("Description = %q", article.Description)
+	}
+	if article.Type != "Formulaire" {
+		t.Errorf("Type = %q", article.Type)
+	}
+	if !strings.Contains(article.Content, "Traitements, salaires et pensions") {
+		t.Errorf("Content missing list item: %q", article.Content)
+	}
+	if strings.Contains(article.Content, "Cookie") {
+		t.Error("Content should not contain filtered text")
+	}
+}
+
+func TestListImpotsCategoriesFixture(t *testing.T) {
+	c, server := newFixtureImpotsClient(t)
+
+	categories, err := c.ListImpotsCategories(context.Background())
+	if err != nil {
+		t.Fatalf("ListImpotsCategories() error = %v", err)
+	}
+
+	if len(categories) != 2 {
+		t.Fatalf("ListImpotsCategories() returned %d categories, want 2: %+v", len(categories), categories)
+	}
+	if categories[0].Name != "Déclarer" || categories[0].URL != server.URL+"/particulier/declarer" {
+		t.Errorf("first category = %+v", categories[0])
+	}
+}
+
+func TestListImpotsCategoriesFixtureFallback(t *testing.T) {
+	server := newFixtureServer(t, nil)
+	c := NewImpotsClient(5*time.Second, WithBaseURL(server.URL))
+
+	categories, err := c.ListImpotsCategories(context.Background())
+	if err != nil {
+		t.Fatalf("ListImpotsCategories() error = %v", err)
+	}
+	if len(categories) != 5 || !strings.HasPrefix(categories[0].URL, server.URL) {
+		t.Errorf("ListImpotsCategories() = %+v, want default categories on the base URL", categories)
+	}
+}
+
+func TestSourceFixture(t *testing.T) {
+	c, _ := newFixtureClient(t)
+	ic, _ := newFixtureImpotsClient(t)
+
+	tests := []struct {
+		source   Source
+		query    string
+		document string
+	}{
+		{source: c, query: "carte identité", document: "/particuliers/vosdroits/F1342"},
+		{source: ic, query: "formulaire 2042", document: "/formulaire/2042/declaration-des-revenus"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source.Name(), func(t *testing.T) {
+			ctx := context.Background()
+
+			results, err := tt.source.Search(ctx, tt.query, 10)
+			if err != nil || len(results) == 0 {
+				t.Fatalf("Search() = %d results, error %v", len(results), err)
+			}
+
+			doc, err := tt.source.GetDocument(ctx, tt.document)
+			if err != nil {
+				t.Fatalf("GetDocument() error = %v", err)
+			}
+			if doc.Title == "" || doc.Content == "" {
+				t.Errorf("GetDocument() = %+v, want title and content", doc)
+			}
+
+			categories, err := tt.source.Categories(ctx)
+			if err != nil || len(categories) == 0 {
+				t.Errorf("Categories() = %d categories, error %v", len(categories), err)
+			}
+		})
+	}
+}
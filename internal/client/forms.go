@@ -0,0 +1,273 @@
+package client
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Form is a Cerfa form referenced by service-public.gouv.fr fiches.
+type Form struct {
+	// Number is the Cerfa number with its version, e.g. "12100*03". The
+	// version is omitted when no fiche states it.
+	Number string
+	Title  string
+	// URL is the page presenting the form or its online version.
+	URL string
+	// PDFURL is the downloadable form.
+	PDFURL string
+	// Fiches lists the fiches referencing the form.
+	Fiches []string
+}
+
+// cerfaPattern matches Cerfa numbers written "12100*03", or following the
+// word cerfa as in "cerfa n°12100" and file names like "cerfa_12100-03.pdf".
+var cerfaPattern = regexp.MustCompile(`\b(\d{5})\s?\*\s?(\d{2})\b|(?i:cerfa)\D{0,4}(\d{5})(?:[*_-](\d{2}))?\b`)
+
+// formLookupResults bounds the number of fiches fetched when looking for a
+// form that is not indexed yet.
+const formLookupResults = 3
+
+// parseCerfaNumber returns the five-digit Cerfa number found in s, and its
+// version if present.
+func parseCerfaNumber(s string) (number, version string, ok bool) {
+	m := cerfaPattern.FindStringSubmatch(s)
+	if m == nil {
+		return "", "", false
+	}
+	return cerfaMatch(m)
+}
+
+// cerfaMatch returns the number and version of a cerfaPattern match.
+func cerfaMatch(m []string) (number, version string, ok bool) {
+	if m[1] != "" {
+		return m[1], m[2], true
+	}
+	return m[3], m[4], true
+}
+
+// defaultMaxForms bounds the form index when the cache does not set
+// MaxEntries.
+const defaultMaxForms = 500
+
+// formIndex indexes the forms found in fetched fiches by Cerfa number. It
+// holds at most max forms; the form least recently found in a fiche is
+// dropped first.
+type formIndex struct {
+	max int
+
+	mu    sync.RWMutex
+	forms map[string]*Form
+	// order holds the indexed numbers, least recently found first.
+	order []string
+}
+
+// newFormIndex returns an index of at most maxForms forms, or
+// defaultMaxForms if maxForms is not positive.
+func newFormIndex(maxForms int) *formIndex {
+	if maxForms <= 0 {
+		maxForms = defaultMaxForms
+	}
+	return &formIndex{max: maxForms, forms: make(map[string]*Form)}
+}
+
+// add merges the forms referenced by article into the index.
+func (idx *formIndex) add(article *Article) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+
+	for _, found := range extractForms(article) {
+		number, _, _ := parseCerfaNumber(found.Number)
+		form, ok := idx.forms[number]
+		if !ok {
+			form = &Form{Number: found.Number}
+			idx.forms[number] = form
+		}
+		if len(found.Number) > len(form.Number) {
+			form.Number = found.Number
+		}
+		if form.Title == "" {
+			form.Title = found.Title
+		}
+		if form.URL == "" {
+			form.URL = found.URL
+		}
+		if form.PDFURL == "" {
+			form.PDFURL = found.PDFURL
+		}
+		if !slices.Contains(form.Fiches, article.URL) {
+			form.Fiches = append(form.Fiches, article.URL)
+		}
+
+		idx.order = slices.DeleteFunc(idx.order, func(n string) bool { return n == number })
+		idx.order = append(idx.order, number)
+	}
+
+	for len(idx.order) > idx.max {
+		delete(idx.forms, idx.order[0])
+		idx.order = idx.order[1:]
+	}
+}
+
+// get returns a copy of the form with the given five-digit number.
+func (idx *formIndex) get(number string) (Form, bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	form, ok := idx.forms[number]
+	if !ok {
+		return Form{}, false
+	}
+	copied := *form
+	copied.Fiches = slices.Clone(form.Fiches)
+	return copied, true
+}
+
+// list returns a copy of every indexed form, sorted by number.
+func (idx *formIndex) list() []Form {
+	idx.mu.RLock()
+	numbers := make([]string, 0, len(idx.forms))
+	for number := range idx.forms {
+		numbers = append(numbers, number)
+	}
+	idx.mu.RUnlock()
+
+	slices.Sort(numbers)
+	forms := make([]Form, 0, len(numbers))
+	for _, number := range numbers {
+		if form, ok := idx.get(number); ok {
+			forms = append(forms, form)
+		}
+	}
+	return forms
+}
+
+// extractForms returns the Cerfa forms referenced by article, from its form
+// links and from numbers quoted in its text.
+func extractForms(article *Article) []Form {
+	var forms []Form
+	byNumber := make(map[string]int)
+
+	add := func(number, version string) *Form {
+		full := number
+		if version != "" {
+			full += "*" + version
+		}
+		if i, ok := byNumber[number]; ok {
+			if version != "" {
+				forms[i].Number = full
+			}
+			return &forms[i]
+		}
+		byNumber[number] = len(forms)
+		forms = append(forms, Form{Number: full, Fiches: []string{article.URL}})
+		return &forms[len(forms)-1]
+	}
+
+	for _, link := range article.Links {
+		if link.Kind != LinkForm {
+			continue
+		}
+		number, version, ok := parseCerfaNumber(link.Text + " " + link.URL)
+		if !ok {
+			continue
+		}
+
+		form := add(number, version)
+		if form.Title == "" {
+			form.Title = link.Text
+		}
+		if strings.HasSuffix(strings.ToLower(link.URL), ".pdf") {
+			if form.PDFURL == "" {
+				form.PDFURL = link.URL
+			}
+		} else if form.URL == "" {
+			form.URL = link.URL
+		}
+	}
+
+	// Forms quoted without a link still tell which fiches need them
+	for _, m := range cerfaPattern.FindAllStringSubmatch(article.Content, -1) {
+		number, version, _ := cerfaMatch(m)
+		add(number, version)
+	}
+
+	return forms
+}
+
+// ListForms returns the Cerfa forms referenced by the fiche at ficheURL,
+// with the other indexed fiches referencing them. With an empty ficheURL it
+// returns the forms indexed from the fiches retrieved so far.
+func (c *Client) ListForms(ctx context.Context, ficheURL string) ([]Form, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if ficheURL == "" {
+		return c.forms.list(), nil
+	}
+
+	article, err := c.GetArticle(ctx, ficheURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// The article may come from the cache, so index its forms again: they
+	// may have been dropped from the index since it was fetched
+	c.forms.add(article)
+
+	forms := extractForms(article)
+	for i, found := range forms {
+		number, _, _ := parseCerfaNumber(found.Number)
+		if indexed, ok := c.forms.get(number); ok {
+			for _, fiche := range indexed.Fiches {
+				if !slices.Contains(forms[i].Fiches, fiche) {
+					forms[i].Fiches = append(forms[i].Fiches, fiche)
+				}
+			}
+		}
+	}
+	return forms, nil
+}
+
+// GetForm looks up a Cerfa form by number, such as "12100*03", "12100" or
+// "cerfa 12100". Forms not found in the fiches retrieved so far are searched
+// for on service-public.gouv.fr.
+func (c *Client) GetForm(ctx context.Context, number string) (*Form, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	cerfa, _, ok := parseCerfaNumber(number)
+	if !ok {
+		cerfa, _, ok = parseCerfaNumber("cerfa " + strings.TrimSpace(number))
+	}
+	if !ok {
+		return nil, errorf(ErrInvalidInput, "invalid Cerfa number: %q", number)
+	}
+
+	if form, ok := c.forms.get(cerfa); ok {
+		return &form, nil
+	}
+
+	// Index the fiches that mention the form
+	results, err := c.SearchProcedures(ctx, "cerfa "+cerfa, formLookupResults)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range results {
+		if !strings.Contains(r.URL, "/vosdroits/") {
+			continue
+		}
+		if _, err := c.GetArticle(ctx, r.URL); err != nil && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+
+	if form, ok := c.forms.get(cerfa); ok {
+		return &form, nil
+	}
+	return nil, errorf(ErrNotFound, "cerfa form %s not found", cerfa)
+}
@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseCerfaNumber(t *testing.T) {
+	tests := []struct {
+		input       string
+		wantNumber  string
+		wantVersion string
+		wantOK      bool
+	}{
+		{"formulaire 12100*03", "12100", "03", true},
+		{"12100 * 03", "12100", "03", true},
+		{"cerfa n°12100", "12100", "", true},
+		{"/files/cerfa_12100-03.pdf", "12100", "03", true},
+		{"https://www.formulaires.service-public.gouv.fr/gf/cerfa_12100.do", "12100", "", true},
+		{"75001-12", "", "", false},
+		{"12100", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			number, version, ok := parseCerfaNumber(tt.input)
+			if number != tt.wantNumber || version != tt.wantVersion || ok != tt.wantOK {
+				t.Errorf("parseCerfaNumber(%q) = %q, %q, %v, want %q, %q, %v", tt.input, number, version, ok, tt.wantNumber, tt.wantVersion, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFormsFixture(t *testing.T) {
+	ctx := context.Background()
+	c, server := newFixtureClient(t)
+	ficheURL := server.URL + "/particuliers/vosdroits/F1342"
+
+	// Nothing is indexed before a fiche has been retrieved
+	forms, err := c.ListForms(ctx, "")
+	if err != nil {
+		t.Fatalf("ListForms() error = %v", err)
+	}
+	if len(forms) != 0 {
+		t.Errorf("ListForms() before any fiche = %+v, want none", forms)
+	}
+
+	forms, err = c.ListForms(ctx, "/particuliers/vosdroits/F1342")
+	if err != nil {
+		t.Fatalf("ListForms(F1342) error = %v", err)
+	}
+	if len(forms) != 2 {
+		t.Fatalf("ListForms(F1342) returned %d forms, want 2: %+v", len(forms), forms)
+	}
+
+	adult := forms[0]
+	if adult.Number != "12100*03" || adult.Title != "Formulaire 12100*03" {
+		t.Errorf("first form = %+v", adult)
+	}
+	if adult.URL != "https://www.formulaires.service-public.gouv.fr/gf/cerfa_12100.do" {
+		t.Errorf("form URL = %q", adult.URL)
+	}
+	// Form pages may be fetched by the live client like any other page
+	if _, _, err := New(5 * time.Second).resolve(adult.URL); err != nil {
+		t.Errorf("resolve(form URL) error = %v", err)
+	}
+	if adult.PDFURL != server.URL+"/files/cerfa_12100-03.pdf" {
+		t.Errorf("form PDF URL = %q", adult.PDFURL)
+	}
+	if !slices.Equal(adult.Fiches, []string{ficheURL}) {
+		t.Errorf("form fiches = %q", adult.Fiches)
+	}
+
+	// Forms quoted in the text are indexed without links
+	minor, err := c.GetForm(ctx, "12101")
+	if err != nil {
+		t.Fatalf("GetForm(12101) error = %v", err)
+	}
+	if minor.Number != "12101*02" || minor.URL != "" || !slices.Equal(minor.Fiches, []string{ficheURL}) {
+		t.Errorf("GetForm(12101) = %+v", minor)
+	}
+
+	all, err := c.ListForms(ctx, "")
+	if err != nil {
+		t.Fatalf("ListForms() error = %v", err)
+	}
+	if len(all) != 2 || all[0].Number != "12100*03" || all[1].Number != "12101*02" {
+		t.Errorf("ListForms() = %+v", all)
+	}
+
+	if _, err := c.GetForm(ctx, "not a number"); err == nil {
+		t.Error("GetForm() with an invalid number should fail")
+	}
+}
+
+func TestGetFormFixtureSearch(t *testing.T) {
+	c, server := newFixtureClient(t)
+
+	// The fiche referencing the form is found through the search page
+	form, err := c.GetForm(context.Background(), "cerfa 12101")
+	if err != nil {
+		t.Fatalf("GetForm() error = %v", err)
+	}
+	if form.Number != "12101*02" || !slices.Equal(form.Fiches, []string{server.URL + "/particuliers/vosdroits/F1342"}) {
+		t.Errorf("GetForm() = %+v", form)
+	}
+}
+
+func TestFormIndexLimit(t *testing.T) {
+	idx := newFormIndex(2)
+	idx.add(&Article{URL: "F1", Content: "Formulaire 12100*03 et formulaire 12101*02."})
+	idx.add(&Article{URL: "F2", Content: "Formulaire 13750*05."})
+
+	// The form least recently found in a fiche is dropped first
+	if _, ok := idx.get("12100"); ok {
+		t.Error("form 12100 should have been dropped")
+	}
+	var numbers []string
+	for _, form := range idx.list() {
+		numbers = append(numbers, form.Number)
+	}
+	if !slices.Equal(numbers, []string{"12101*02", "13750*05"}) {
+		t.Errorf("list() = %q, want the 2 most recent forms", numbers)
+	}
+}
+
+func TestListFormsAfterEviction(t *testing.T) {
+	ctx := context.Background()
+	server := newFixtureServer(t, servicePublicPages)
+	// The index holds as many forms as the cache holds entries
+	c := New(5*time.Second, WithBaseURL(server.URL), fixtureCrawl, WithCache(CacheConfig{MaxEntries: 1, ArticleTTL: time.Hour}))
+
+	// The fiche references 2 forms, more than the index holds, and is
+	// served from the cache the second time
+	for range 2 {
+		forms, err := c.ListForms(ctx, "/particuliers/vosdroits/F1342")
+		if err != nil {
+			t.Fatalf("ListForms(F1342) error = %v", err)
+		}
+		if len(forms) != 2 || forms[0].Number != "12100*03" || forms[1].Number != "12101*02" {
+			t.Errorf("ListForms(F1342) = %+v, want both forms of the fiche", forms)
+		}
+	}
+}
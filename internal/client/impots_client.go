@@ -11,39 +11,47 @@ import (
 	"github.com/gocolly/colly/v2"
 )
 
+// impots describes impots.gouv.fr.
+var impots = site{
+	name:    "impots.gouv.fr",
+	baseURL: "https://www.impots.gouv.fr",
+	hosts:   []string{"www.impots.gouv.fr", "impots.gouv.fr"},
+}
+
 // ImpotsClient handles HTTP requests to impots.gouv.fr using Colly for web scraping.
 type ImpotsClient struct {
-	collector *colly.Collector
-	baseURL   string
-	timeout   time.Duration
+	fetcher *fetcher
 }
 
 // NewImpotsClient creates a new ImpotsClient with the specified timeout.
-func NewImpotsClient(timeout time.Duration) *ImpotsClient {
-	c := colly.NewCollector(
-		colly.AllowedDomains("www.impots.gouv.fr", "impots.gouv.fr"),
-		colly.UserAgent("VosDroits-MCP-Server/1.0"),
-		colly.Async(false),
-	)
-
-	c.SetRequestTimeout(timeout)
-
-	err := c.Limit(&colly.LimitRule{
-		DomainGlob:  "*.impots.gouv.fr",
-		Parallelism: 1,
-		Delay:       1 * time.Second,
-	})
-	if err != nil {
-		// Fallback without rate limiting if it fails
-	}
-
+func NewImpotsClient(timeout time.Duration, opts ...Option) *ImpotsClient {
 	return &ImpotsClient{
-		collector: c,
-		baseURL:   "https://www.impots.gouv.fr",
-		timeout:   timeout,
+		fetcher: newFetcher(impots, timeout, newOptions(opts)),
 	}
 }
 
+// Name returns the canonical domain of impots.gouv.fr.
+func (c *ImpotsClient) Name() string {
+	return c.fetcher.site.name
+}
+
+// Owns reports whether rawURL is an absolute URL on impots.gouv.fr.
+func (c *ImpotsClient) Owns(rawURL string) bool {
+	return c.fetcher.owns(rawURL)
+}
+
+// SnapshotDate returns when the offline snapshot served by the client was
+// taken, or the zero time when pages are fetched live from impots.gouv.fr.
+func (c *ImpotsClient) SnapshotDate() time.Time {
+	return c.fetcher.snapshotDate()
+}
+
+// Degraded reports whether impots.gouv.fr is failing and requests to it are
+// short-circuited, so that results come from the cache and may be outdated.
+func (c *ImpotsClient) Degraded() bool {
+	return c.fetcher.degraded(c.fetcher.site.baseURL)
+}
+
 // ImpotsSearchResult represents a search result from impots.gouv.fr.
 type ImpotsSearchResult struct {
 	Title       string
@@ -53,8 +61,24 @@ type ImpotsSearchResult struct {
 	Date        string
 }
 
-// SearchImpots searches for tax information matching the query.
+// SearchImpots searches for tax information matching the query. It fails if
+// the website cannot be reached and returns no results if nothing matches.
 func (c *ImpotsClient) SearchImpots(ctx context.Context, query string, limit int) ([]ImpotsSearchResult, error) {
+	page, err := c.SearchImpotsPage(ctx, query, limit, "")
+	if err != nil {
+		return nil, err
+	}
+	if page.EmptyReason == EmptySiteError || page.EmptyReason == EmptyOffline {
+		return nil, page.Err
+	}
+	return page.Results, nil
+}
+
+// SearchImpotsPage returns a page of up to limit results matching the query,
+// following the site's pagination as needed. An empty cursor starts at the
+// first result; pass SearchPage.NextCursor to get the following page. When
+// there are no results, SearchPage.EmptyReason says why.
+func (c *ImpotsClient) SearchImpotsPage(ctx context.Context, query string, limit int, cursor string) (*SearchPage[ImpotsSearchResult], error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
@@ -63,108 +87,136 @@ func (c *ImpotsClient) SearchImpots(ctx context.Context, query string, limit int
 		limit = 10
 	}
 
-	var results []ImpotsSearchResult
-	errorChan := make(chan error, 1)
+	searchURL := fmt.Sprintf("%s/recherche/%s?origin[]=impots&search_filter=Filtrer",
+		c.fetcher.site.baseURL, url.QueryEscape(query))
+	start := searchCursor{Search: searchURL, URL: searchURL, Page: 1}
+	if cursor != "" {
+		var err error
+		if start, err = c.fetcher.resumeSearch(start, cursor, "/recherche/"); err != nil {
+			return nil, err
+		}
+	}
 
-	scraper := c.collector.Clone()
+	page, err := collectResults(ctx, start, limit, c.searchPage)
+	if err != nil {
+		return nil, err
+	}
+	return offlineResults(c.fetcher, page), nil
+}
 
-	// Allow URL revisits to prevent "already visited" errors on repeated calls
-	scraper.AllowURLRevisit = true
+// searchPage scrapes one page of search results.
+func (c *ImpotsClient) searchPage(ctx context.Context, pageURL string) (*resultsPage[ImpotsSearchResult], error) {
+	cacheKey := "search:" + pageURL
+	if page, ok := fromCache[*resultsPage[ImpotsSearchResult]](c.fetcher.cache, opSearch, cacheKey); ok {
+		return page, nil
+	}
 
-	done := make(chan struct{})
-	defer close(done)
+	var page resultsPage[ImpotsSearchResult]
 
-	go func() {
-		select {
-		case <-ctx.Done():
-			scraper = nil
-		case <-done:
-		}
-	}()
+	err := c.fetcher.visit(ctx, pageURL, func(scraper *colly.Collector) {
+		// Handle search results - impots.gouv.fr uses div.fr-card
+		scraper.OnHTML("div.fr-card", func(e *colly.HTMLElement) {
+			href := e.ChildAttr("a[href]", "href")
+			if href == "" {
+				return
+			}
 
-	// Handle search results - impots.gouv.fr uses div.fr-card
-	scraper.OnHTML("div.fr-card", func(e *colly.HTMLElement) {
-		if len(results) >= limit {
-			return
-		}
+			fullURL := e.Request.AbsoluteURL(href)
 
-		href := e.ChildAttr("a[href]", "href")
-		if href == "" {
-			return
-		}
+			title := strings.TrimSpace(e.ChildText("h3.fr-card__title a"))
+			if title == "" {
+				title = strings.TrimSpace(e.ChildText("h3.fr-card__title"))
+			}
 
-		fullURL := e.Request.AbsoluteURL(href)
+			resultType := strings.TrimSpace(e.ChildText("div.fr-card__detail"))
+			date := strings.TrimSpace(e.ChildText("p.fr-card__detail"))
 
-		title := strings.TrimSpace(e.ChildText("h3.fr-card__title a"))
-		if title == "" {
-			title = strings.TrimSpace(e.ChildText("h3.fr-card__title"))
-		}
+			description := strings.TrimSpace(e.ChildText("p.fr-card__desc"))
 
-		resultType := strings.TrimSpace(e.ChildText("div.fr-card__detail"))
-		date := strings.TrimSpace(e.ChildText("p.fr-card__detail"))
+			if title != "" && fullURL != "" {
+				page.items = append(page.items, ImpotsSearchResult{
+					Title:       title,
+					URL:         fullURL,
+					Description: description,
+					Type:        resultType,
+					Date:        date,
+				})
+			}
+		})
 
-		description := strings.TrimSpace(e.ChildText("p.fr-card__desc"))
+		// Total number of results, e.g. "42 résultats"
+		scraper.OnHTML("h1, h2, [class*='results-count'], [class*='nb-result']", func(e *colly.HTMLElement) {
+			if n, ok := parseResultCount(e.Text); ok && page.total == 0 {
+				page.total = n
+			}
+		})
 
-		if title != "" && fullURL != "" {
-			results = append(results, ImpotsSearchResult{
-				Title:       title,
-				URL:         fullURL,
-				Description: description,
-				Type:        resultType,
-				Date:        date,
+		// The "no results" message and the alternative queries it offers
+		scraper.OnHTML("main", func(e *colly.HTMLElement) {
+			page.noMatch = isNoResultsText(e.Text)
+			e.ForEach(suggestionSelector, func(_ int, elem *colly.HTMLElement) {
+				page.suggestions = appendUnique(page.suggestions, elem.Text)
 			})
-		}
-	})
+		})
 
-	scraper.OnError(func(r *colly.Response, err error) {
-		select {
-		case errorChan <- fmt.Errorf("scraping error: %w", err):
-		default:
-		}
+		// Link to the next page of results
+		scraper.OnHTML("li.pager__item--next a[href], a[rel='next'][href]", func(e *colly.HTMLElement) {
+			if page.next == "" {
+				page.next = e.Request.AbsoluteURL(e.Attr("href"))
+			}
+		})
 	})
-
-	searchURL := fmt.Sprintf("%s/recherche/%s?origin[]=impots&search_filter=Filtrer",
-		c.baseURL, url.QueryEscape(query))
-
-	if err := scraper.Visit(searchURL); err != nil {
-		return c.fallbackImpotsSearch(ctx, query, limit)
+	if err != nil && len(page.items) == 0 {
+		if stale, ok := fromStaleCache[*resultsPage[ImpotsSearchResult]](c.fetcher.cache, cacheKey, err); ok {
+			return stale, nil
+		}
+		return nil, err
 	}
 
-	scraper.Wait()
+	c.fetcher.cache.set(opSearch, cacheKey, &page)
+	return &page, nil
+}
 
-	select {
-	case err := <-errorChan:
-		if len(results) == 0 {
-			return nil, err
-		}
-	default:
+// Search implements Source by delegating to SearchImpots.
+func (c *ImpotsClient) Search(ctx context.Context, query string, limit int) ([]Result, error) {
+	results, err := c.SearchImpots(ctx, query, limit)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(results) == 0 {
-		return c.fallbackImpotsSearch(ctx, query, limit)
+	out := make([]Result, len(results))
+	for i, r := range results {
+		out[i] = Result(r)
 	}
-
-	return results, nil
-}
-
-func (c *ImpotsClient) fallbackImpotsSearch(ctx context.Context, query string, limit int) ([]ImpotsSearchResult, error) {
-	return []ImpotsSearchResult{
-		{
-			Title:       fmt.Sprintf("No results found for: %s", query),
-			URL:         fmt.Sprintf("%s/recherche/%s?origin[]=impots&search_filter=Filtrer", c.baseURL, url.QueryEscape(query)),
-			Description: "Try modifying your search terms or visit the website directly.",
-			Type:        "Info",
-		},
-	}, nil
+	return out, nil
 }
 
 // ImpotsArticle represents an article from impots.gouv.fr.
 type ImpotsArticle struct {
-	Title       string
-	Content     string
+	Title   string
+	Content string
+	// Markdown is Content with headings, lists, emphasis, links and tables
+	// kept as Markdown.
+	Markdown    string
 	URL         string
 	Type        string
 	Description string
+	// Tables holds the tables of the article, such as tax brackets.
+	Tables []Table
+	// Links holds the outbound links of the article: forms, online services
+	// and legal texts.
+	Links []Link
+}
+
+// impotsMarkdownSelector matches the blocks rendered in ImpotsArticle.Markdown.
+const impotsMarkdownSelector = "h1, h2, h3, h4, p, ul, ol, table, div.fr-callout, div.fr-card__desc"
+
+// isImpotsText reports whether text is article content rather than scripts,
+// cookie banners or navigation.
+func isImpotsText(text string) bool {
+	return !strings.Contains(text, "javascript") &&
+		!strings.Contains(text, "Cookie") &&
+		!strings.Contains(text, "Navigation")
 }
 
 // GetImpotsArticle retrieves an article from the specified URL.
@@ -173,121 +225,108 @@ func (c *ImpotsClient) GetImpotsArticle(ctx context.Context, articleURL string)
 		return nil, err
 	}
 
-	// Validate URL is not empty
-	if articleURL == "" {
-		return nil, fmt.Errorf("URL cannot be empty")
-	}
-
-	parsedURL, err := url.Parse(articleURL)
+	// Validate URL - accept both www.impots.gouv.fr and impots.gouv.fr
+	articleURL, _, err := c.fetcher.resolve(articleURL)
 	if err != nil {
-		return nil, fmt.Errorf("invalid URL: %w", err)
-	}
-
-	// Ensure it's an impots.gouv.fr URL (check for empty host or relative URLs)
-	if parsedURL.Host == "" {
-		// Handle relative URLs by making them absolute
-		articleURL = c.baseURL + articleURL
-		parsedURL, err = url.Parse(articleURL)
-		if err != nil {
-			return nil, fmt.Errorf("invalid URL after making absolute: %w", err)
-		}
+		return nil, err
 	}
 
-	// Check domain - accept both www.impots.gouv.fr and impots.gouv.fr
-	host := strings.ToLower(parsedURL.Host)
-	if host != "impots.gouv.fr" && host != "www.impots.gouv.fr" {
-		return nil, fmt.Errorf("URL must be from impots.gouv.fr domain, got: %s", parsedURL.Host)
+	cacheKey := "article:" + articleURL
+	if cached, ok := fromCache[*ImpotsArticle](c.fetcher.cache, opArticle, cacheKey); ok {
+		return cached, nil
 	}
 
 	var article ImpotsArticle
 	article.URL = articleURL
-	errorChan := make(chan error, 1)
-
-	scraper := c.collector.Clone()
-
-	// Allow URL revisits to prevent "already visited" errors on repeated calls
-	scraper.AllowURLRevisit = true
-
-	done := make(chan struct{})
-	defer close(done)
 
-	go func() {
-		select {
-		case <-ctx.Done():
-			scraper = nil
-		case <-done:
-		}
-	}()
-
-	scraper.OnHTML("head", func(e *colly.HTMLElement) {
-		if article.Title == "" {
-			article.Title = strings.TrimSpace(e.ChildText("title"))
-			article.Title = strings.Split(article.Title, " | ")[0]
-		}
-		if article.Description == "" {
-			article.Description = e.ChildAttr("meta[property='og:title']", "content")
-		}
-	})
-
-	scraper.OnHTML("main, article, div.main-content, div.content", func(e *colly.HTMLElement) {
-		if article.Content == "" {
-			var contentParts []string
-
-			e.ForEach("h1, h2, h3, p, li, div.fr-callout, div.fr-card__desc", func(_ int, elem *colly.HTMLElement) {
-				text := strings.TrimSpace(elem.Text)
-				if text != "" &&
-					!strings.Contains(text, "javascript") &&
-					!strings.Contains(text, "Cookie") &&
-					!strings.Contains(text, "Navigation") &&
-					len(text) > 10 {
-					contentParts = append(contentParts, text)
-				}
-			})
-
-			article.Content = strings.Join(contentParts, "\n\n")
-		}
-	})
-
-	scraper.OnHTML("div.fr-breadcrumb", func(e *colly.HTMLElement) {
-		breadcrumb := strings.TrimSpace(e.Text)
-		if strings.Contains(breadcrumb, "Formulaire") {
-			article.Type = "Formulaire"
-		} else if strings.Contains(breadcrumb, "Question") {
-			article.Type = "Question-Réponse"
-		} else {
-			article.Type = "Article"
-		}
+	err = c.fetcher.visit(ctx, articleURL, func(scraper *colly.Collector) {
+		scraper.OnHTML("head", func(e *colly.HTMLElement) {
+			if article.Title == "" {
+				article.Title = strings.TrimSpace(e.ChildText("title"))
+				article.Title = strings.Split(article.Title, " | ")[0]
+			}
+			if article.Description == "" {
+				article.Description = e.ChildAttr("meta[property='og:title']", "content")
+			}
+		})
+
+		scraper.OnHTML("main, article, div.main-content, div.content", func(e *colly.HTMLElement) {
+			if article.Content == "" {
+				var contentParts []string
+
+				e.ForEach("h1, h2, h3, p, li, div.fr-callout, div.fr-card__desc", func(_ int, elem *colly.HTMLElement) {
+					text := strings.TrimSpace(elem.Text)
+					if text != "" && isImpotsText(text) && len(text) > 10 {
+						contentParts = append(contentParts, text)
+					}
+				})
+
+				article.Content = strings.Join(contentParts, "\n\n")
+				article.Markdown = renderMarkdown(e, impotsMarkdownSelector, isImpotsText)
+				article.Tables = parseTables(e, "table")
+				article.Links = parseLinks(e, "a[href]")
+			}
+		})
+
+		scraper.OnHTML("div.fr-breadcrumb", func(e *colly.HTMLElement) {
+			breadcrumb := strings.TrimSpace(e.Text)
+			if strings.Contains(breadcrumb, "Formulaire") {
+				article.Type = "Formulaire"
+			} else if strings.Contains(breadcrumb, "Question") {
+				article.Type = "Question-Réponse"
+			} else {
+				article.Type = "Article"
+			}
+		})
 	})
-
-	scraper.OnError(func(r *colly.Response, err error) {
-		select {
-		case errorChan <- fmt.Errorf("failed to fetch article: %w", err):
-		default:
+	if err != nil {
+		if stale, ok := fromStaleCache[*ImpotsArticle](c.fetcher.cache, cacheKey, err); ok {
+			return stale, nil
 		}
-	})
-
-	if err := scraper.Visit(articleURL); err != nil {
-		return nil, fmt.Errorf("failed to visit article page: %w", err)
-	}
-
-	scraper.Wait()
-
-	select {
-	case err := <-errorChan:
 		return nil, err
-	default:
 	}
 
 	if article.Title == "" {
 		article.Title = "Article from impots.gouv.fr"
 	}
 	if article.Content == "" {
-		return nil, fmt.Errorf("no content found at URL: %s", articleURL)
+		return nil, errorf(ErrParseFailure, "no content found at URL: %s", articleURL)
 	}
 
+	c.fetcher.cache.set(opArticle, cacheKey, &article)
 	return &article, nil
 }
 
+// GetPDF retrieves a PDF document from impots.gouv.fr, such as the notice of
+// a tax form, and extracts its text page by page.
+func (c *ImpotsClient) GetPDF(ctx context.Context, documentURL string) (*PDFDocument, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	documentURL, _, err := c.fetcher.resolve(documentURL)
+	if err != nil {
+		return nil, err
+	}
+	return c.fetcher.getPDF(ctx, documentURL)
+}
+
+// GetDocument implements Source by delegating to GetImpotsArticle.
+func (c *ImpotsClient) GetDocument(ctx context.Context, documentURL string) (*Document, error) {
+	article, err := c.GetImpotsArticle(ctx, documentURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Document{
+		Title:       article.Title,
+		URL:         article.URL,
+		Description: article.Description,
+		Type:        article.Type,
+		Content:     article.Content,
+	}, nil
+}
+
 // ImpotsCategoryInfo represents a tax category.
 type ImpotsCategoryInfo struct {
 	Name        string
@@ -301,97 +340,89 @@ func (c *ImpotsClient) ListImpotsCategories(ctx context.Context) ([]ImpotsCatego
 		return nil, err
 	}
 
-	var categories []ImpotsCategoryInfo
-	errorChan := make(chan error, 1)
-
-	scraper := c.collector.Clone()
-
-	// Allow URL revisits to prevent "already visited" errors on repeated calls
-	scraper.AllowURLRevisit = true
-
-	done := make(chan struct{})
-	defer close(done)
-
-	go func() {
-		select {
-		case <-ctx.Done():
-			scraper = nil
-		case <-done:
-		}
-	}()
+	if categories, ok := fromCache[[]ImpotsCategoryInfo](c.fetcher.cache, opList, "categories"); ok {
+		return categories, nil
+	}
 
-	scraper.OnHTML("nav.fr-nav a.fr-nav__link", func(e *colly.HTMLElement) {
-		name := strings.TrimSpace(e.Text)
-		href := e.Attr("href")
+	var categories []ImpotsCategoryInfo
 
-		if name != "" && href != "" && name != "Accueil" {
-			fullURL := e.Request.AbsoluteURL(href)
-			for _, cat := range categories {
-				if cat.Name == name {
-					return
+	err := c.fetcher.visit(ctx, c.fetcher.site.baseURL+"/particulier", func(scraper *colly.Collector) {
+		scraper.OnHTML("nav.fr-nav a.fr-nav__link", func(e *colly.HTMLElement) {
+			name := strings.TrimSpace(e.Text)
+			href := e.Attr("href")
+
+			if name != "" && href != "" && name != "Accueil" {
+				fullURL := e.Request.AbsoluteURL(href)
+				for _, cat := range categories {
+					if cat.Name == name {
+						return
+					}
 				}
-			}
 
-			categories = append(categories, ImpotsCategoryInfo{
-				Name:        name,
-				Description: fmt.Sprintf("Information fiscale pour %s", strings.ToLower(name)),
-				URL:         fullURL,
-			})
-		}
-	})
-
-	scraper.OnError(func(r *colly.Response, err error) {
-		select {
-		case errorChan <- fmt.Errorf("failed to fetch categories: %w", err):
-		default:
-		}
+				categories = append(categories, ImpotsCategoryInfo{
+					Name:        name,
+					Description: fmt.Sprintf("Information fiscale pour %s", strings.ToLower(name)),
+					URL:         fullURL,
+				})
+			}
+		})
 	})
-
-	if err := scraper.Visit(c.baseURL + "/particulier"); err != nil {
-		return c.getDefaultImpotsCategories(), nil
-	}
-
-	scraper.Wait()
-
-	select {
-	case <-errorChan:
-		return c.getDefaultImpotsCategories(), nil
-	default:
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
 	}
 
-	if len(categories) == 0 {
+	if err != nil || len(categories) == 0 {
+		if stale, ok := fromStaleCache[[]ImpotsCategoryInfo](c.fetcher.cache, "categories", err); ok {
+			return stale, nil
+		}
 		return c.getDefaultImpotsCategories(), nil
 	}
 
+	c.fetcher.cache.set(opList, "categories", categories)
 	return categories, nil
 }
 
 func (c *ImpotsClient) getDefaultImpotsCategories() []ImpotsCategoryInfo {
+	baseURL := c.fetcher.site.baseURL
 	return []ImpotsCategoryInfo{
 		{
 			Name:        "Particulier",
 			Description: "Information fiscale pour les particuliers",
-			URL:         c.baseURL + "/particulier",
+			URL:         baseURL + "/particulier",
 		},
 		{
 			Name:        "Professionnel",
 			Description: "Information fiscale pour les professionnels",
-			URL:         c.baseURL + "/professionnel",
+			URL:         baseURL + "/professionnel",
 		},
 		{
 			Name:        "Partenaire",
 			Description: "Information pour les partenaires",
-			URL:         c.baseURL + "/partenaire",
+			URL:         baseURL + "/partenaire",
 		},
 		{
 			Name:        "Collectivité",
 			Description: "Information pour les collectivités",
-			URL:         c.baseURL + "/collectivite",
+			URL:         baseURL + "/collectivite",
 		},
 		{
 			Name:        "International",
 			Description: "Information fiscale internationale",
-			URL:         c.baseURL + "/international",
+			URL:         baseURL + "/international",
 		},
 	}
 }
+
+// Categories implements Source by delegating to ListImpotsCategories.
+func (c *ImpotsClient) Categories(ctx context.Context) ([]Category, error) {
+	categories, err := c.ListImpotsCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]Category, len(categories))
+	for i, cat := range categories {
+		out[i] = Category(cat)
+	}
+	return out, nil
+}
@@ -11,11 +11,8 @@ func TestNewImpotsClient(t *testing.T) {
 	if client == nil {
 		t.Fatal("NewImpotsClient returned nil")
 	}
-	if client.baseURL != "https://www.impots.gouv.fr" {
-		t.Errorf("expected baseURL to be https://www.impots.gouv.fr, got %s", client.baseURL)
-	}
-	if client.timeout != 30*time.Second {
-		t.Errorf("expected timeout to be 30s, got %v", client.timeout)
+	if client.fetcher.site.baseURL != "https://www.impots.gouv.fr" {
+		t.Errorf("expected baseURL to be https://www.impots.gouv.fr, got %s", client.fetcher.site.baseURL)
 	}
 }
 
@@ -24,34 +21,40 @@ func TestSearchImpots_InvalidInput(t *testing.T) {
 		name  string
 		query string
 		limit int
+		want  int
 	}{
 		{
 			name:  "empty query",
 			query: "",
 			limit: 10,
+			want:  0,
 		},
 		{
 			name:  "negative limit",
 			query: "formulaire 2042",
 			limit: -1,
+			want:  3,
 		},
 		{
 			name:  "excessive limit",
 			query: "formulaire 2042",
 			limit: 200,
+			want:  3,
 		},
 	}
 
-	client := NewImpotsClient(30 * time.Second)
+	client, _ := newFixtureImpotsClient(t)
 	ctx := context.Background()
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := client.SearchImpots(ctx, tt.query, tt.limit)
-			// Empty query should return fallback results, not error
+			results, err := client.SearchImpots(ctx, tt.query, tt.limit)
 			// Invalid limits should be normalized, not error
-			if err != nil && tt.query != "" {
-				t.Errorf("SearchImpots() unexpected error: %v", err)
+			if err != nil {
+				t.Fatalf("SearchImpots() unexpected error: %v", err)
+			}
+			if len(results) != tt.want {
+				t.Errorf("SearchImpots() returned %d results, want %d", len(results), tt.want)
 			}
 		})
 	}
@@ -80,12 +83,13 @@ func TestGetImpotsArticle_InvalidURL(t *testing.T) {
 		},
 		{
 			name:    "valid domain",
-			url:     "https://www.impots.gouv.fr/formulaire/2042/declaration-des-revenus",
+			url:     "/formulaire/2042/declaration-des-revenus",
 			wantErr: false,
 		},
 	}
 
-	client := NewImpotsClient(30 * time.Second)
+	// Relative URLs resolve against the fixture server
+	client, _ := newFixtureImpotsClient(t)
 	ctx := context.Background()
 
 	for _, tt := range tests {
@@ -123,25 +127,3 @@ func TestListImpotsCategories_DefaultCategories(t *testing.T) {
 		}
 	}
 }
-
-func TestFallbackImpotsSearch(t *testing.T) {
-	client := NewImpotsClient(30 * time.Second)
-	ctx := context.Background()
-
-	results, err := client.fallbackImpotsSearch(ctx, "test query", 10)
-	if err != nil {
-		t.Fatalf("fallbackImpotsSearch returned error: %v", err)
-	}
-
-	if len(results) == 0 {
-		t.Fatal("fallbackImpotsSearch returned empty results")
-	}
-
-	if results[0].Title == "" {
-		t.Error("fallback result has empty title")
-	}
-
-	if results[0].URL == "" {
-		t.Error("fallback result has empty URL")
-	}
-}
@@ -7,8 +7,8 @@ import (
 )
 
 func TestListLifeEvents(t *testing.T) {
-	// Create client with reasonable timeout
-	c := New(30 * time.Second)
+	// Serve the saved "comment faire si" page
+	c, _ := newFixtureClient(t)
 	ctx := context.Background()
 
 	events, err := c.ListLifeEvents(ctx)
@@ -53,11 +53,11 @@ func TestListLifeEvents(t *testing.T) {
 }
 
 func TestGetLifeEventDetails(t *testing.T) {
-	c := New(30 * time.Second)
+	c, server := newFixtureClient(t)
 	ctx := context.Background()
 
-	// Test with "J'attends un enfant" URL
-	testURL := "https://www.service-public.gouv.fr/particuliers/vosdroits/F16225"
+	// Test with the saved "J'attends un enfant" page
+	testURL := server.URL + "/particuliers/vosdroits/F16225"
 
 	details, err := c.GetLifeEventDetails(ctx, testURL)
 	if err != nil {
@@ -0,0 +1,155 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gocolly/colly/v2"
+)
+
+// LimitConfig bounds the responses accepted from the websites. Sizes are
+// counted after decompression.
+type LimitConfig struct {
+	// MaxPageSize is the largest web page accepted, in bytes.
+	MaxPageSize int64
+	// MaxDocumentSize is the largest PDF document accepted, in bytes.
+	MaxDocumentSize int64
+}
+
+// DefaultLimitConfig is the limit policy used unless WithLimits is given.
+var DefaultLimitConfig = LimitConfig{
+	MaxPageSize:     5 << 20,
+	MaxDocumentSize: 20 << 20,
+}
+
+// limitTransport is an http.RoundTripper that only lets through web pages
+// and PDF documents of bounded size. Other successful responses, such as
+// images or archives, fail with ErrBinaryDocument before their body is read,
+// and bodies growing past the limit fail with ErrTooLarge. Compressed bodies
+// are decompressed here so that the limit also bounds decompression.
+type limitTransport struct {
+	base http.RoundTripper
+	cfg  LimitConfig
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	pageURL := req.URL.String()
+
+	if !resp.Uncompressed && strings.Contains(strings.ToLower(resp.Header.Get("Content-Encoding")), "gzip") {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			resp.Body.Close()
+			return nil, errorf(ErrUpstreamUnavailable, "invalid compressed response from %s: %w", pageURL, err)
+		}
+		resp.Body = &gzipBody{Reader: gz, body: resp.Body}
+		resp.Header.Del("Content-Encoding")
+		resp.Header.Del("Content-Length")
+		resp.ContentLength = -1
+		resp.Uncompressed = true
+	}
+
+	limit := t.cfg.MaxPageSize
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		mediaType := sniffMediaType(resp)
+		switch {
+		case isPageMediaType(mediaType):
+		case mediaType == "application/pdf":
+			limit = t.cfg.MaxDocumentSize
+		default:
+			resp.Body.Close()
+			return nil, errorf(ErrBinaryDocument, "%s is a %s file, not a web page", pageURL, mediaType)
+		}
+	}
+
+	if limit > 0 {
+		if resp.ContentLength > limit {
+			resp.Body.Close()
+			return nil, errorf(ErrTooLarge, "response from %s is %d bytes, more than the limit of %d", pageURL, resp.ContentLength, limit)
+		}
+		resp.Body = &limitedBody{ReadCloser: resp.Body, remaining: limit, limit: limit, url: pageURL}
+	}
+	return resp, nil
+}
+
+// sniffMediaType returns the media type of resp, detected from the start of
+// its body when the header is missing or generic.
+func sniffMediaType(resp *http.Response) string {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "" && mediaType != "application/octet-stream" {
+		return mediaType
+	}
+
+	buffered := bufio.NewReaderSize(resp.Body, 512)
+	head, _ := buffered.Peek(512)
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{buffered, resp.Body}
+
+	mediaType, _, _ = mime.ParseMediaType(http.DetectContentType(head))
+	return mediaType
+}
+
+// isPageMediaType reports whether mediaType is parsed as a web page.
+func isPageMediaType(mediaType string) bool {
+	return strings.HasPrefix(mediaType, "text/") ||
+		strings.HasSuffix(mediaType, "+xml") ||
+		mediaType == "application/xml" ||
+		mediaType == "application/json"
+}
+
+// isPDF reports whether r holds a PDF document.
+func isPDF(r *colly.Response) bool {
+	if r.Headers != nil {
+		if mediaType, _, _ := mime.ParseMediaType(r.Headers.Get("Content-Type")); mediaType == "application/pdf" {
+			return true
+		}
+	}
+	return bytes.HasPrefix(r.Body, []byte("%PDF-"))
+}
+
+// limitedBody fails with ErrTooLarge once more than limit bytes are read.
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	limit     int64
+	url       string
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining <= 0 {
+		// Anything left beyond the limit is an error; a clean end is not
+		var probe [1]byte
+		if n, err := b.ReadCloser.Read(probe[:]); n == 0 {
+			return 0, err
+		}
+		return 0, errorf(ErrTooLarge, "response from %s is larger than the limit of %d bytes", b.url, b.limit)
+	}
+	if int64(len(p)) > b.remaining {
+		p = p[:b.remaining]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.remaining -= int64(n)
+	return n, err
+}
+
+// gzipBody decompresses body and closes both readers.
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (b *gzipBody) Close() error {
+	b.Reader.Close()
+	return b.body.Close()
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gocolly/colly/v2"
+)
+
+// samplePDF is the start of a PDF document, enough to be recognised.
+const samplePDF = "%PDF-1.4\n1 0 obj << /Type /Catalog >> endobj\n%%EOF\n"
+
+func newLimitServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/page":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = io.WriteString(w, "<html><body><h1>Page</h1></body></html>")
+		case "/image.png":
+			w.Header().Set("Content-Type", "image/png")
+			_, _ = w.Write(bytes.Repeat([]byte{0x89}, 1024))
+		case "/form.pdf":
+			// Servers often send PDFs as generic binaries
+			w.Header().Set("Content-Type", "application/octet-stream")
+			_, _ = io.WriteString(w, samplePDF)
+		case "/declared-large":
+			w.Header().Set("Content-Type", "text/html")
+			w.Header().Set("Content-Length", "4096")
+			_, _ = w.Write(bytes.Repeat([]byte("a"), 4096))
+		case "/streamed-large":
+			w.Header().Set("Content-Type", "text/html")
+			w.(http.Flusher).Flush()
+			_, _ = w.Write(bytes.Repeat([]byte("a"), 4096))
+		case "/bomb":
+			// A small compressed body that expands past the limit
+			w.Header().Set("Content-Type", "text/html")
+			w.Header().Set("Content-Encoding", "gzip")
+			gz := gzip.NewWriter(w)
+			_, _ = gz.Write(bytes.Repeat([]byte("a"), 1<<20))
+			_ = gz.Close()
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestLimitTransport(t *testing.T) {
+	server := newLimitServer(t)
+
+	// Without transparent decompression, as when a server compresses unasked
+	base := &http.Transport{DisableCompression: true}
+	transport := &limitTransport{base: base, cfg: LimitConfig{MaxPageSize: 1024, MaxDocumentSize: 2048}}
+
+	tests := []struct {
+		path string
+		want error
+	}{
+		{"/page", nil},
+		{"/form.pdf", nil},
+		{"/image.png", ErrBinaryDocument},
+		{"/declared-large", ErrTooLarge},
+		{"/streamed-large", ErrTooLarge},
+		{"/bomb", ErrTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, server.URL+tt.path, nil)
+			resp, err := transport.RoundTrip(req)
+			if err == nil {
+				_, err = io.ReadAll(resp.Body)
+				resp.Body.Close()
+			}
+			if !errors.Is(err, tt.want) || (tt.want == nil && err != nil) {
+				t.Errorf("RoundTrip(%s) error = %v, want %v", tt.path, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLimitTransportExactLimit(t *testing.T) {
+	server := newLimitServer(t)
+	transport := &limitTransport{base: http.DefaultTransport, cfg: LimitConfig{MaxPageSize: 4096}}
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/streamed-large", nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil || len(body) != 4096 {
+		t.Errorf("ReadAll() = %d bytes, %v; want the whole body at the limit", len(body), err)
+	}
+}
+
+func TestVisitRoutesPDFs(t *testing.T) {
+	server := newLimitServer(t)
+	c := New(5*time.Second, WithBaseURL(server.URL), fixtureCrawl)
+	ctx := context.Background()
+
+	// Pages scrapers see binary documents as errors, not empty pages
+	for _, path := range []string{"/form.pdf", "/image.png"} {
+		if _, err := c.GetArticle(ctx, server.URL+path); !errors.Is(err, ErrBinaryDocument) {
+			t.Errorf("GetArticle(%s) error = %v, want ErrBinaryDocument", path, err)
+		}
+	}
+
+	var got []byte
+	err := c.fetcher.visitDocument(ctx, server.URL+"/form.pdf", func(*colly.Collector) {}, func(r *colly.Response) error {
+		got = r.Body
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("visitDocument() error = %v", err)
+	}
+	if !strings.HasPrefix(string(got), "%PDF-") {
+		t.Errorf("PDF handler got %q", got)
+	}
+}
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/gocolly/colly/v2"
+)
+
+// LinkKind classifies the target of a link found in an article.
+type LinkKind string
+
+const (
+	// LinkFiche points to a service-public.gouv.fr fiche pratique (F-page).
+	LinkFiche LinkKind = "fiche"
+	// LinkCategory points to a service-public.gouv.fr category page (N-page).
+	LinkCategory LinkKind = "category"
+	// LinkQuestion points to a service-public.gouv.fr question-réponse.
+	LinkQuestion LinkKind = "question"
+	// LinkTeleservice points to an online procedure.
+	LinkTeleservice LinkKind = "teleservice"
+	// LinkForm points to a Cerfa form or a PDF document.
+	LinkForm LinkKind = "form"
+	// LinkLaw points to a legal text, for example on Légifrance.
+	LinkLaw LinkKind = "law"
+	// LinkOther is any other link.
+	LinkOther LinkKind = "other"
+)
+
+// Link is an outbound link of an article.
+type Link struct {
+	Text string
+	URL  string
+	Kind LinkKind
+}
+
+var (
+	// ficheIDPattern matches service-public.gouv.fr page identifiers such as
+	// F1342, F1342Q, N358 or R45813.
+	ficheIDPattern = regexp.MustCompile(`^([FNR])\d+(Q\d*)?$`)
+
+	// lawHosts lists the websites publishing legal texts.
+	lawHosts = []string{"legifrance.gouv.fr", "eur-lex.europa.eu", "bofip.impots.gouv.fr"}
+)
+
+// parseLinks extracts the links of e matching selector, made absolute and
+// classified. Anchors, scripts and duplicate URLs are skipped.
+func parseLinks(e *colly.HTMLElement, selector string) []Link {
+	var links []Link
+	seen := make(map[string]bool)
+
+	e.ForEach(selector, func(_ int, a *colly.HTMLElement) {
+		href := strings.TrimSpace(a.Attr("href"))
+		if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(href, "javascript:") || strings.HasPrefix(href, "mailto:") {
+			return
+		}
+
+		linkURL := a.Request.AbsoluteURL(href)
+		if linkURL == "" || seen[linkURL] {
+			return
+		}
+
+		text := strings.Join(strings.Fields(a.Text), " ")
+		if text == "" {
+			text = strings.TrimSpace(a.Attr("title"))
+		}
+		if text == "" {
+			return
+		}
+
+		seen[linkURL] = true
+		links = append(links, Link{
+			Text: text,
+			URL:  linkURL,
+			Kind: classifyLink(linkURL, text),
+		})
+	})
+
+	return links
+}
+
+// classifyLink returns the kind of the link to linkURL labelled text.
+func classifyLink(linkURL, text string) LinkKind {
+	u, err := url.Parse(linkURL)
+	if err != nil {
+		return LinkOther
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	lowerURL := strings.ToLower(linkURL)
+	lowerText := strings.ToLower(text)
+
+	switch {
+	case strings.HasSuffix(strings.ToLower(u.Path), ".pdf"),
+		strings.Contains(lowerURL, "cerfa"),
+		strings.Contains(lowerText, "cerfa"):
+		return LinkForm
+	case hasHost(host, lawHosts):
+		return LinkLaw
+	}
+
+	// Page identifiers only have a meaning on service-public.gouv.fr
+	if strings.Contains(u.Path, "/vosdroits/") {
+		if m := ficheIDPattern.FindStringSubmatch(path.Base(u.Path)); m != nil {
+			switch {
+			case m[1] == "N":
+				return LinkCategory
+			case m[1] == "R" && strings.Contains(lowerText, "formulaire"):
+				return LinkForm
+			case m[1] == "R":
+				return LinkTeleservice
+			case m[2] != "":
+				return LinkQuestion
+			default:
+				return LinkFiche
+			}
+		}
+	}
+
+	if strings.Contains(lowerText, "téléservice") || strings.Contains(lowerText, "en ligne") {
+		return LinkTeleservice
+	}
+
+	return LinkOther
+}
+
+// hasHost reports whether host is one of hosts or a subdomain of one.
+func hasHost(host string, hosts []string) bool {
+	for _, h := range hosts {
+		if host == h || strings.HasSuffix(host, "."+h) {
+			return true
+		}
+	}
+	return false
+}
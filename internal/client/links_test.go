@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"testing"
+)
+
+func TestClassifyLink(t *testing.T) {
+	tests := []struct {
+		url  string
+		text string
+		want LinkKind
+	}{
+		{"https://www.service-public.gouv.fr/particuliers/vosdroits/F1342", "Carte d'identité", LinkFiche},
+		{"https://www.service-public.gouv.fr/particuliers/vosdroits/N358", "Papiers d'identité", LinkCategory},
+		{"https://www.service-public.gouv.fr/particuliers/vosdroits/F1342Q", "Peut-on ... ?", LinkQuestion},
+		{"https://www.service-public.gouv.fr/particuliers/vosdroits/R45813", "Pré-demande", LinkTeleservice},
+		{"https://www.service-public.gouv.fr/particuliers/vosdroits/R11200", "Formulaire de déclaration", LinkForm},
+		{"https://www.formulaires.service-public.gouv.fr/gf/cerfa_12100.do", "Formulaire 12100*03", LinkForm},
+		{"https://www.impots.gouv.fr/sites/default/files/formulaires/2042/2042.PDF", "Télécharger", LinkForm},
+		{"https://www.legifrance.gouv.fr/codes/article_lc/LEGIARTI000006419292", "Code civil : article 47", LinkLaw},
+		{"https://bofip.impots.gouv.fr/bofip/1234-PGP.html", "BOI-IR", LinkLaw},
+		{"https://www.impots.gouv.fr/accueil/particulier/espace", "Déclarer en ligne", LinkTeleservice},
+		{"https://ants.gouv.fr/", "ANTS", LinkOther},
+		{"https://www.service-public.gouv.fr/particuliers/actualites/A1234", "Actualité", LinkOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if got := classifyLink(tt.url, tt.text); got != tt.want {
+				t.Errorf("classifyLink(%q, %q) = %q, want %q", tt.url, tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinksFixture(t *testing.T) {
+	ctx := context.Background()
+	c, server := newFixtureClient(t)
+
+	article, err := c.GetArticle(ctx, "/particuliers/vosdroits/F1342")
+	if err != nil {
+		t.Fatalf("GetArticle() error = %v", err)
+	}
+
+	want := []Link{
+		{Text: "dispositif de recueil", URL: server.URL + "/particuliers/vosdroits/F21089", Kind: LinkFiche},
+		{Text: "Papiers d'identité", URL: server.URL + "/particuliers/vosdroits/N358", Kind: LinkCategory},
+		{Text: "Peut-on faire sa carte d'identité dans une autre commune ?", URL: server.URL + "/particuliers/vosdroits/F1342Q", Kind: LinkQuestion},
+		{Text: "Pré-demande de carte d'identité", URL: server.URL + "/particuliers/vosdroits/R45813", Kind: LinkTeleservice},
+		{Text: "Formulaire 12100*03", URL: "https://www.formulaires.service-public.gouv.fr/gf/cerfa_12100.do", Kind: LinkForm},
+		{Text: "Notice", URL: server.URL + "/files/notice-carte-identite.pdf", Kind: LinkForm},
+		{Text: "Télécharger le formulaire", URL: server.URL + "/files/cerfa_12100-03.pdf", Kind: LinkForm},
+		{Text: "Décret n°55-1397", URL: "https://www.legifrance.gouv.fr/loda/id/JORFTEXT000000704392", Kind: LinkLaw},
+		{Text: "ANTS", URL: "https://ants.gouv.fr/", Kind: LinkOther},
+	}
+	if len(article.Links) != len(want) {
+		t.Fatalf("got %d links, want %d: %+v", len(article.Links), len(want), article.Links)
+	}
+	for i, w := range want {
+		if article.Links[i] != w {
+			t.Errorf("link %d = %+v, want %+v", i, article.Links[i], w)
+		}
+	}
+
+	impotsClient, impotsServer := newFixtureImpotsClient(t)
+	impotsArticle, err := impotsClient.GetImpotsArticle(ctx, "/formulaire/2042/declaration-des-revenus")
+	if err != nil {
+		t.Fatalf("GetImpotsArticle() error = %v", err)
+	}
+	wantImpots := []Link{
+		{Text: "Télécharger le formulaire 2042", URL: impotsServer.URL + "/sites/default/files/formulaires/2042/2025/2042_5191.pdf", Kind: LinkForm},
+		{Text: "déclarer en ligne", URL: impotsServer.URL + "/accueil/particulier/espace", Kind: LinkTeleservice},
+	}
+	if len(impotsArticle.Links) != len(wantImpots) {
+		t.Fatalf("got %d impots links, want %d: %+v", len(impotsArticle.Links), len(wantImpots), impotsArticle.Links)
+	}
+	for i, w := range wantImpots {
+		if impotsArticle.Links[i] != w {
+			t.Errorf("impots link %d = %+v, want %+v", i, impotsArticle.Links[i], w)
+		}
+	}
+}
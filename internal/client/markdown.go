@@ -0,0 +1,236 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gocolly/colly/v2"
+	"golang.org/x/net/html"
+)
+
+// renderMarkdown renders the elements of e matching selector as Markdown
+// blocks in document order. Elements nested inside another match are part of
+// that match, and blocks whose text keep rejects are skipped. Links are made
+// absolute against the page URL.
+func renderMarkdown(e *colly.HTMLElement, selector string, keep func(text string) bool) string {
+	md := markdownRenderer{absURL: e.Request.AbsoluteURL}
+
+	var blocks []string
+	e.ForEach(selector, func(_ int, elem *colly.HTMLElement) {
+		if elem.DOM.ParentsUntilSelection(e.DOM).Filter(selector).Length() > 0 {
+			return
+		}
+		text := strings.TrimSpace(elem.Text)
+		if text == "" || !keep(text) {
+			return
+		}
+		if block := md.block(elem.DOM.Get(0)); block != "" {
+			blocks = append(blocks, block)
+		}
+	})
+
+	return strings.Join(blocks, "\n\n")
+}
+
+// markdownRenderer converts HTML nodes to Markdown.
+type markdownRenderer struct {
+	absURL func(href string) string
+}
+
+// block renders n as a Markdown block.
+func (m markdownRenderer) block(n *html.Node) string {
+	if level := headingLevel(n.Data); level > 0 {
+		return strings.Repeat("#", level) + " " + m.inline(n)
+	}
+
+	switch n.Data {
+	case "ul", "ol":
+		return m.list(n, 0)
+	case "table":
+		return m.table(n)
+	case "p", "span", "a", "strong", "b", "em", "i":
+		return m.inline(n)
+	}
+
+	// Containers such as callouts may hold several blocks
+	var blocks []string
+	var inline []*html.Node
+	flush := func() {
+		if text := m.inlineNodes(inline); text != "" {
+			blocks = append(blocks, text)
+		}
+		inline = nil
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && isBlockTag(c.Data) {
+			flush()
+			if b := m.block(c); b != "" {
+				blocks = append(blocks, b)
+			}
+			continue
+		}
+		inline = append(inline, c)
+	}
+	flush()
+
+	return strings.Join(blocks, "\n\n")
+}
+
+// inline renders the children of n as a single line of Markdown.
+func (m markdownRenderer) inline(n *html.Node) string {
+	var children []*html.Node
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		children = append(children, c)
+	}
+	return m.inlineNodes(children)
+}
+
+func (m markdownRenderer) inlineNodes(nodes []*html.Node) string {
+	var b strings.Builder
+	for _, n := range nodes {
+		m.writeInline(&b, n)
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+func (m markdownRenderer) writeInline(b *strings.Builder, n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		b.WriteString(n.Data)
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+
+	switch n.Data {
+	case "script", "style", "button", "svg":
+		return
+	case "br":
+		b.WriteString(" ")
+		return
+	case "strong", "b":
+		m.wrap(b, n, "**")
+		return
+	case "em", "i":
+		m.wrap(b, n, "*")
+		return
+	case "a":
+		text := m.inline(n)
+		href := strings.TrimSpace(attr(n, "href"))
+		if text == "" || href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(href, "javascript:") {
+			b.WriteString(text)
+			return
+		}
+		if abs := m.absURL(href); abs != "" {
+			href = abs
+		}
+		fmt.Fprintf(b, "[%s](%s)", text, href)
+		return
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		m.writeInline(b, c)
+	}
+}
+
+// wrap writes the inline content of n surrounded by marker. The content is
+// trimmed because Markdown ignores markers followed or preceded by a space.
+func (m markdownRenderer) wrap(b *strings.Builder, n *html.Node, marker string) {
+	if text := m.inline(n); text != "" {
+		b.WriteString(marker + text + marker)
+	}
+}
+
+// list renders a ul or ol element, indenting nested lists by depth.
+func (m markdownRenderer) list(n *html.Node, depth int) string {
+	var lines []string
+	indent := strings.Repeat("  ", depth)
+	number := 0
+
+	for li := n.FirstChild; li != nil; li = li.NextSibling {
+		if li.Type != html.ElementNode || li.Data != "li" {
+			continue
+		}
+		number++
+
+		marker := "-"
+		if n.Data == "ol" {
+			marker = fmt.Sprintf("%d.", number)
+		}
+
+		var text []*html.Node
+		var nested []string
+		for c := li.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == html.ElementNode && (c.Data == "ul" || c.Data == "ol") {
+				nested = append(nested, m.list(c, depth+1))
+				continue
+			}
+			text = append(text, c)
+		}
+
+		lines = append(lines, indent+marker+" "+m.inlineNodes(text))
+		lines = append(lines, nested...)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// table renders a table element as a Markdown table, preceded by its caption.
+// Markdown tables need a header row, so the first row is used when the table
+// has no headers.
+func (m markdownRenderer) table(n *html.Node) string {
+	table := extractTable(n, func(cell *html.Node) string {
+		return strings.ReplaceAll(m.inline(cell), "|", "\\|")
+	})
+
+	rows := table.Rows
+	header := table.Headers
+	if header == nil && len(rows) > 0 {
+		header, rows = rows[0], rows[1:]
+	}
+	if header == nil {
+		return table.Caption
+	}
+
+	width := len(header)
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+	line := func(row []string) string {
+		for len(row) < width {
+			row = append(row, "")
+		}
+		return "| " + strings.Join(row, " | ") + " |"
+	}
+
+	var lines []string
+	if table.Caption != "" {
+		lines = append(lines, "**"+table.Caption+"**", "")
+	}
+	lines = append(lines, line(header), "|"+strings.Repeat(" --- |", width))
+	for _, row := range rows {
+		lines = append(lines, line(row))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// isBlockTag reports whether tag starts a new Markdown block.
+func isBlockTag(tag string) bool {
+	switch tag {
+	case "p", "div", "ul", "ol", "table", "section", "blockquote":
+		return true
+	}
+	return headingLevel(tag) > 0
+}
+
+// attr returns the value of the named attribute of n.
+func attr(n *html.Node, name string) string {
+	for _, a := range n.Attr {
+		if a.Key == name {
+			return a.Val
+		}
+	}
+	return ""
+}
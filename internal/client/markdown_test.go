@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestMarkdownRendererBlock(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "heading",
+			input: `<h3>Prendre  rendez-vous</h3>`,
+			want:  "### Prendre rendez-vous",
+		},
+		{
+			name:  "emphasis and links",
+			input: `<p>C'est <strong>gratuit</strong>, voir <a href="/particuliers/vosdroits/F21089">cette fiche</a> ou <a href="#top">le haut</a>.</p>`,
+			want:  "C'est **gratuit**, voir [cette fiche](https://www.service-public.gouv.fr/particuliers/vosdroits/F21089) ou le haut.",
+		},
+		{
+			name:  "nested lists",
+			input: `<ol><li>Photo</li><li>Justificatif<ul><li>Facture</li></ul></li></ol>`,
+			want:  "1. Photo\n2. Justificatif\n  - Facture",
+		},
+		{
+			name:  "table with caption",
+			input: `<table><caption>Tarifs</caption><tr><th>Situation</th><th>Coût</th></tr><tr><td>Perte | vol</td></tr></table>`,
+			want:  "**Tarifs**\n\n| Situation | Coût |\n| --- | --- |\n| Perte \\| vol |  |",
+		},
+		{
+			name:  "container with several blocks",
+			input: `<div class="fr-callout"><p>Attention</p><ul><li>Délai</li></ul></div>`,
+			want:  "Attention\n\n- Délai",
+		},
+	}
+
+	md := markdownRenderer{absURL: func(href string) string {
+		if strings.HasPrefix(href, "/") {
+			return "https://www.service-public.gouv.fr" + href
+		}
+		return href
+	}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := html.Parse(strings.NewReader("<html><body>" + tt.input + "</body></html>"))
+			if err != nil {
+				t.Fatalf("html.Parse() error = %v", err)
+			}
+			body := doc.FirstChild.LastChild
+
+			if got := md.block(body.FirstChild); got != tt.want {
+				t.Errorf("block() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkdownFixture(t *testing.T) {
+	ctx := context.Background()
+	c, server := newFixtureClient(t)
+
+	article, err := c.GetArticle(ctx, "/particuliers/vosdroits/F1342")
+	if err != nil {
+		t.Fatalf("GetArticle() error = %v", err)
+	}
+	for _, want := range []string{
+		"La carte d'identité est un document officiel",
+		"## Où faire la demande ?",
+		"### Prendre rendez-vous",
+		"[dispositif de recueil](" + server.URL + "/particuliers/vosdroits/F21089)",
+		"- Justificatif de domicile\n  - Facture d'électricité",
+		"est **gratuite** pour",
+		"| Perte ou vol | 25 € |",
+	} {
+		if !strings.Contains(article.Markdown, want) {
+			t.Errorf("article Markdown missing %q:\n%s", want, article.Markdown)
+		}
+	}
+	if strings.Contains(article.Markdown, "Abonnement") {
+		t.Error("article Markdown should not contain filtered text")
+	}
+
+	details, err := c.GetLifeEventDetails(ctx, "/particuliers/vosdroits/F16225")
+	if err != nil {
+		t.Fatalf("GetLifeEventDetails() error = %v", err)
+	}
+	if md := details.Sections[0].Markdown; !strings.Contains(md, "- Examens prénataux obligatoires pris en charge") {
+		t.Errorf("life event section Markdown = %q", md)
+	}
+
+	impotsClient, _ := newFixtureImpotsClient(t)
+	impotsArticle, err := impotsClient.GetImpotsArticle(ctx, "/formulaire/2042/declaration-des-revenus")
+	if err != nil {
+		t.Fatalf("GetImpotsArticle() error = %v", err)
+	}
+	if !strings.HasPrefix(impotsArticle.Markdown, "# Formulaire 2042") ||
+		!strings.Contains(impotsArticle.Markdown, "- Traitements, salaires et pensions") {
+		t.Errorf("impots article Markdown = %q", impotsArticle.Markdown)
+	}
+	if strings.Contains(impotsArticle.Markdown, "Cookie") {
+		t.Error("impots article Markdown should not contain filtered text")
+	}
+}
@@ -0,0 +1,145 @@
+package client
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Option configures a Client or ImpotsClient.
+type Option func(*options)
+
+// options holds the settings applied by Option values.
+type options struct {
+	baseURL string
+	hosts   []string
+	cache   CacheConfig
+
+	crawl   *CrawlConfig
+	limits  *LimitConfig
+	retry   *RetryConfig
+	breaker *BreakerConfig
+
+	pageCacheDir string
+	pageCacheTTL time.Duration
+
+	snapshot *Snapshot
+	recorder *Snapshot
+}
+
+// WithBaseURL points the client at baseURL instead of the official website,
+// for example an httptest server serving saved pages. Unless WithAllowedHosts
+// is also given, only the host of baseURL may be fetched.
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) {
+		o.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithAllowedHosts replaces the list of host names the client may fetch.
+func WithAllowedHosts(hosts ...string) Option {
+	return func(o *options) {
+		o.hosts = hosts
+	}
+}
+
+// WithCache enables the in-memory cache of scraped results.
+func WithCache(cfg CacheConfig) Option {
+	return func(o *options) {
+		o.cache = cfg
+	}
+}
+
+// WithCrawl replaces DefaultCrawlConfig as the crawl policy.
+func WithCrawl(cfg CrawlConfig) Option {
+	return func(o *options) {
+		o.crawl = &cfg
+	}
+}
+
+// WithLimits replaces DefaultLimitConfig as the bounds on responses.
+func WithLimits(cfg LimitConfig) Option {
+	return func(o *options) {
+		o.limits = &cfg
+	}
+}
+
+// WithRetry replaces DefaultRetryConfig as the policy for retrying transient
+// failures.
+func WithRetry(cfg RetryConfig) Option {
+	return func(o *options) {
+		o.retry = &cfg
+	}
+}
+
+// WithBreaker replaces DefaultBreakerConfig as the circuit breaker settings.
+func WithBreaker(cfg BreakerConfig) Option {
+	return func(o *options) {
+		o.breaker = &cfg
+	}
+}
+
+// WithPageCache stores fetched pages in dir. Pages younger than ttl are served
+// from disk; older ones are revalidated with the website before reuse. The
+// directory is not bounded in size and is never pruned.
+func WithPageCache(dir string, ttl time.Duration) Option {
+	return func(o *options) {
+		o.pageCacheDir = dir
+		o.pageCacheTTL = ttl
+	}
+}
+
+// WithSnapshot serves every page from snap. The client never contacts the
+// website; pages missing from the snapshot fail as if the site were down.
+func WithSnapshot(snap *Snapshot) Option {
+	return func(o *options) {
+		o.snapshot = snap
+	}
+}
+
+// WithSnapshotRecorder copies every page the client fetches into snap.
+func WithSnapshotRecorder(snap *Snapshot) Option {
+	return func(o *options) {
+		o.recorder = snap
+	}
+}
+
+// apply returns a copy of s with the options applied.
+func (o *options) apply(s site) site {
+	if o.baseURL != "" {
+		s.baseURL = o.baseURL
+		s.hosts = nil
+		if u, err := url.Parse(o.baseURL); err == nil && u.Hostname() != "" {
+			s.hosts = []string{strings.ToLower(u.Hostname())}
+		}
+	}
+	if len(o.hosts) > 0 {
+		s.hosts = make([]string, len(o.hosts))
+		for i, h := range o.hosts {
+			s.hosts[i] = strings.ToLower(h)
+		}
+	}
+	return s
+}
+
+// crawlConfig returns the crawl policy, with the default user agent if none
+// is set.
+func (o *options) crawlConfig() CrawlConfig {
+	cfg := DefaultCrawlConfig
+	if o.crawl != nil {
+		cfg = *o.crawl
+	}
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = DefaultUserAgent
+	}
+	return cfg
+}
+
+// newOptions collects opts into an options value.
+func newOptions(opts []Option) *options {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
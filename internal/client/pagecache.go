@@ -0,0 +1,207 @@
+package client
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// storedHeaders lists the response headers kept in the page cache.
+var storedHeaders = []string{"Content-Type", "ETag", "Last-Modified"}
+
+// cachedPage is a fetched page as stored on disk.
+type cachedPage struct {
+	URL       string            `json:"url"`
+	Header    map[string]string `json:"header"`
+	Body      []byte            `json:"body"`
+	FetchedAt time.Time         `json:"fetched_at"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+// pageCacheTransport is an http.RoundTripper that stores successful GET
+// responses on disk. Fresh pages are served without contacting the website;
+// expired pages are revalidated with If-None-Match and If-Modified-Since so
+// that unchanged pages cost a 304 instead of a full download. The cache is
+// not bounded: entries are only replaced, never evicted.
+type pageCacheTransport struct {
+	base http.RoundTripper
+	dir  string
+	ttl  time.Duration
+	now  func() time.Time
+}
+
+// newPageCacheTransport wraps base with a page cache stored in dir.
+func newPageCacheTransport(base http.RoundTripper, dir string, ttl time.Duration) *pageCacheTransport {
+	return &pageCacheTransport{
+		base: base,
+		dir:  dir,
+		ttl:  ttl,
+		now:  time.Now,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *pageCacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("Authorization") != "" {
+		return t.base.RoundTrip(req)
+	}
+
+	key := req.URL.String()
+	page, err := t.load(key)
+	if err != nil && !os.IsNotExist(err) {
+		slog.Warn("Ignoring unreadable page cache entry", "url", key, "error", err)
+	}
+
+	if page != nil && t.now().Before(page.ExpiresAt) {
+		slog.Debug("Page cache hit", "url", key)
+		return page.response(req), nil
+	}
+
+	// Revalidate the stored copy instead of downloading it again
+	if page != nil {
+		req = req.Clone(req.Context())
+		if etag := page.Header["ETag"]; etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := page.Header["Last-Modified"]; lastModified != "" {
+			req.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	resp, err := t.base.RoundTrip(req)
+
+	// Serve the expired copy rather than nothing while the website is down,
+	// but not in place of a refusal such as robots.txt or the URL policy
+	if page != nil && (Retryable(err) || isTransient(resp, err)) {
+		if resp != nil {
+			resp.Body.Close()
+		}
+		slog.Warn("Serving stale page from cache", "url", key, "status", statusOf(resp), "error", err)
+		return page.response(req), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && page != nil {
+		resp.Body.Close()
+		slog.Debug("Page cache revalidated", "url", key)
+		page.ExpiresAt = t.now().Add(t.ttl)
+		t.store(page)
+		return page.response(req), nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	page = &cachedPage{
+		URL:       key,
+		Header:    make(map[string]string),
+		Body:      body,
+		FetchedAt: t.now(),
+		ExpiresAt: t.now().Add(t.ttl),
+	}
+	for _, name := range storedHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			page.Header[name] = value
+		}
+	}
+	t.store(page)
+
+	return resp, nil
+}
+
+// path returns the file holding the page for key.
+func (t *pageCacheTransport) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	name := hex.EncodeToString(sum[:])
+	return filepath.Join(t.dir, name[:2], name+".json")
+}
+
+func (t *pageCacheTransport) load(key string) (*cachedPage, error) {
+	data, err := os.ReadFile(t.path(key))
+	if err != nil {
+		return nil, err
+	}
+
+	var page cachedPage
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil, fmt.Errorf("failed to decode cached page: %w", err)
+	}
+	if page.URL != key {
+		return nil, os.ErrNotExist
+	}
+	return &page, nil
+}
+
+// store writes page atomically. Failures are logged: the cache is an
+// optimisation and must never fail a request.
+func (t *pageCacheTransport) store(page *cachedPage) {
+	if err := t.write(page); err != nil {
+		slog.Warn("Failed to store page in cache", "url", page.URL, "error", err)
+	}
+}
+
+func (t *pageCacheTransport) write(page *cachedPage) error {
+	path := t.path(page.URL)
+
+	data, err := json.Marshal(page)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so readers never see a partial page
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// response builds an HTTP response serving the stored page for req.
+func (p *cachedPage) response(req *http.Request) *http.Response {
+	header := make(http.Header, len(p.Header))
+	for name, value := range p.Header {
+		header.Set(name, value)
+	}
+
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(p.Body)),
+		ContentLength: int64(len(p.Body)),
+		Request:       req,
+	}
+}
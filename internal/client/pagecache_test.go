@@ -0,0 +1,163 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestPageCacheTransport(t *testing.T) {
+	var requests, revalidations int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			revalidations++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = io.WriteString(w, "<html><body>page</body></html>")
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	now := time.Now()
+	transport := newPageCacheTransport(http.DefaultTransport, dir, time.Hour)
+	transport.now = func() time.Time { return now }
+	httpClient := &http.Client{Transport: transport}
+
+	get := func(c *http.Client) string {
+		t.Helper()
+		resp, err := c.Get(server.URL + "/page")
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("status = %d, want 200", resp.StatusCode)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+
+	// First fetch downloads and stores the page
+	if body := get(httpClient); body != "<html><body>page</body></html>" {
+		t.Fatalf("body = %q", body)
+	}
+
+	// Fresh pages are served from disk without contacting the server
+	get(httpClient)
+	if requests != 1 {
+		t.Errorf("requests = %d after fresh hit, want 1", requests)
+	}
+
+	// A new transport over the same directory starts warm
+	restarted := newPageCacheTransport(http.DefaultTransport, dir, time.Hour)
+	restarted.now = transport.now
+	get(&http.Client{Transport: restarted})
+	if requests != 1 {
+		t.Errorf("requests = %d after restart, want 1", requests)
+	}
+
+	// Expired pages are revalidated and reused on 304
+	now = now.Add(2 * time.Hour)
+	if body := get(httpClient); body != "<html><body>page</body></html>" {
+		t.Errorf("revalidated body = %q", body)
+	}
+	if requests != 2 || revalidations != 1 {
+		t.Errorf("requests = %d, revalidations = %d, want 2 and 1", requests, revalidations)
+	}
+
+	// Revalidation refreshes the expiry
+	get(httpClient)
+	if requests != 2 {
+		t.Errorf("requests = %d after revalidation, want 2", requests)
+	}
+}
+
+func TestPageCacheTransportSkipsErrors(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{Transport: newPageCacheTransport(http.DefaultTransport, t.TempDir(), time.Hour)}
+
+	for i := 0; i < 2; i++ {
+		resp, err := httpClient.Get(server.URL)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("status = %d, want 404", resp.StatusCode)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("requests = %d, error responses must not be cached", requests)
+	}
+}
+
+// roundTripFunc is an http.RoundTripper calling itself.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestPageCacheTransportStale(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "page")
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		err       error
+		wantStale bool
+	}{
+		{"upstream unavailable", errorf(ErrUpstreamUnavailable, "HTTP error 503"), true},
+		{"connection reset", syscall.ECONNRESET, true},
+		{"disallowed by robots.txt", errorf(ErrDisallowed, "robots.txt does not allow it"), false},
+		{"forbidden redirect", errorf(ErrForbiddenDomain, "redirect refused"), false},
+		{"too large", errorf(ErrTooLarge, "response too large"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			now := time.Now()
+			warm := newPageCacheTransport(http.DefaultTransport, dir, time.Hour)
+			resp, err := (&http.Client{Transport: warm}).Get(server.URL)
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			resp.Body.Close()
+
+			failing := newPageCacheTransport(roundTripFunc(func(*http.Request) (*http.Response, error) {
+				return nil, tt.err
+			}), dir, time.Hour)
+			failing.now = func() time.Time { return now.Add(2 * time.Hour) }
+
+			req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+			resp, err = failing.RoundTrip(req)
+			if tt.wantStale {
+				if err != nil {
+					t.Fatalf("RoundTrip() error = %v, want the stale page", err)
+				}
+				resp.Body.Close()
+				return
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("RoundTrip() = %v, %v; want the error %v", resp, err, tt.err)
+			}
+		})
+	}
+}
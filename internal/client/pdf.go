@@ -0,0 +1,160 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gocolly/colly/v2"
+	"github.com/ledongthuc/pdf"
+)
+
+// PDFDocument is the text and metadata of a PDF document, such as a Cerfa
+// form or a tax notice.
+type PDFDocument struct {
+	URL     string
+	Title   string
+	Author  string
+	Subject string
+	// Created is when the document was written, or the zero time if unknown.
+	Created time.Time
+	// Pages holds the text of every page, in order.
+	Pages []PDFPage
+}
+
+// PDFPage is the text of one page of a PDFDocument.
+type PDFPage struct {
+	// Number starts at 1.
+	Number int
+	Text   string
+}
+
+// Text returns the text of all pages, separated by blank lines.
+func (d *PDFDocument) Text() string {
+	texts := make([]string, 0, len(d.Pages))
+	for _, page := range d.Pages {
+		if page.Text != "" {
+			texts = append(texts, page.Text)
+		}
+	}
+	return strings.Join(texts, "\n\n")
+}
+
+// getPDF downloads the PDF document at pageURL, which must already be
+// resolved, and extracts its text.
+func (f *fetcher) getPDF(ctx context.Context, pageURL string) (*PDFDocument, error) {
+	cacheKey := "pdf:" + pageURL
+	if cached, ok := fromCache[*PDFDocument](f.cache, opArticle, cacheKey); ok {
+		return cached, nil
+	}
+
+	var doc *PDFDocument
+	isPage := false
+	err := f.visitDocument(ctx, pageURL, func(scraper *colly.Collector) {
+		scraper.OnHTML("html", func(*colly.HTMLElement) {
+			isPage = true
+		})
+	}, func(r *colly.Response) error {
+		var err error
+		doc, err = parsePDF(r.Body)
+		if err != nil {
+			return errorf(ErrParseFailure, "failed to read PDF document %s: %w", pageURL, err)
+		}
+		return nil
+	})
+	if err != nil {
+		if stale, ok := fromStaleCache[*PDFDocument](f.cache, cacheKey, err); ok {
+			return stale, nil
+		}
+		return nil, err
+	}
+	if doc == nil {
+		if isPage {
+			return nil, errorf(ErrInvalidInput, "%s is a web page, not a PDF document", pageURL)
+		}
+		return nil, errorf(ErrParseFailure, "no PDF document found at URL: %s", pageURL)
+	}
+
+	doc.URL = pageURL
+	if doc.Text() == "" {
+		return nil, errorf(ErrParseFailure, "no text found in PDF document %s; it may be a scanned image", pageURL)
+	}
+
+	f.cache.set(opArticle, cacheKey, doc)
+	return doc, nil
+}
+
+// parsePDF extracts the metadata and the text of each page of a PDF
+// document. Text is rebuilt line by line from the position of each run.
+func parsePDF(data []byte) (doc *PDFDocument, err error) {
+	// The PDF reader panics on some malformed files
+	defer func() {
+		if r := recover(); r != nil {
+			doc, err = nil, fmt.Errorf("malformed PDF: %v", r)
+		}
+	}()
+
+	reader, err := pdf.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	info := reader.Trailer().Key("Info")
+	doc = &PDFDocument{
+		Title:   strings.TrimSpace(info.Key("Title").Text()),
+		Author:  strings.TrimSpace(info.Key("Author").Text()),
+		Subject: strings.TrimSpace(info.Key("Subject").Text()),
+		Created: parsePDFDate(info.Key("CreationDate").Text()),
+	}
+
+	for i := 1; i <= reader.NumPage(); i++ {
+		rows, err := reader.Page(i).GetTextByRow()
+		if err != nil {
+			return nil, fmt.Errorf("page %d: %w", i, err)
+		}
+
+		lines := make([]string, 0, len(rows))
+		for _, row := range rows {
+			if line := rowText(row.Content); line != "" {
+				lines = append(lines, line)
+			}
+		}
+		doc.Pages = append(doc.Pages, PDFPage{Number: i, Text: strings.Join(lines, "\n")})
+	}
+	return doc, nil
+}
+
+// rowText joins the runs of a line of text, adding a space where there is a
+// gap between two runs.
+func rowText(runs []pdf.Text) string {
+	var b strings.Builder
+	for i, run := range runs {
+		if i > 0 {
+			prev := runs[i-1]
+			if gap := run.X - (prev.X + prev.W); gap > prev.FontSize*0.2 && !strings.HasSuffix(prev.S, " ") {
+				b.WriteByte(' ')
+			}
+		}
+		b.WriteString(run.S)
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// parsePDFDate parses a PDF date such as "D:20250402091500+02'00'",
+// returning the zero time if it is invalid.
+func parsePDFDate(s string) time.Time {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "D:")
+	s = strings.ReplaceAll(s, "'", "")
+	if i := strings.IndexByte(s, 'Z'); i >= 0 {
+		// UTC, parsed without an offset
+		s = s[:i]
+	}
+	for _, layout := range []string{"20060102150405-0700", "20060102150405", "200601021504", "20060102"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
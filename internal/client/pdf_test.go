@@ -0,0 +1,120 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsePDF(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "impots", "notice-2042.pdf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := parsePDF(data)
+	if err != nil {
+		t.Fatalf("parsePDF() error = %v", err)
+	}
+
+	if doc.Title != "Notice 2042 - Déclaration des revenus 2024" {
+		t.Errorf("Title = %q", doc.Title)
+	}
+	if doc.Author != "Direction générale des Finances publiques" {
+		t.Errorf("Author = %q", doc.Author)
+	}
+	if want := time.Date(2025, 4, 2, 9, 15, 0, 0, time.FixedZone("", 2*60*60)); !doc.Created.Equal(want) {
+		t.Errorf("Created = %v, want %v", doc.Created, want)
+	}
+
+	if len(doc.Pages) != 2 {
+		t.Fatalf("got %d pages, want 2", len(doc.Pages))
+	}
+	wantPages := []string{
+		"Notice de la déclaration des revenus\nCette notice vous aide à remplir la déclaration n° 2042.\nVous devez déclarer l'ensemble des revenus perçus en 2024.",
+		"Traitements et salaires\nLes salaires sont préremplis dans les cases 1AJ et 1BJ.\nVérifiez les montants et corrigez-les si nécessaire.",
+	}
+	for i, want := range wantPages {
+		if doc.Pages[i].Number != i+1 || doc.Pages[i].Text != want {
+			t.Errorf("page %d = %d %q, want %q", i+1, doc.Pages[i].Number, doc.Pages[i].Text, want)
+		}
+	}
+}
+
+func TestParsePDFMalformed(t *testing.T) {
+	for name, data := range map[string]string{
+		"not a PDF": "<html><body>Maintenance</body></html>",
+		"truncated": "%PDF-1.4\n1 0 obj << /Type /Catalog",
+	} {
+		if _, err := parsePDF([]byte(data)); err == nil {
+			t.Errorf("parsePDF(%s) should fail", name)
+		}
+	}
+}
+
+func TestParsePDFDate(t *testing.T) {
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"D:20250402091500+02'00'", time.Date(2025, 4, 2, 9, 15, 0, 0, time.FixedZone("", 2*60*60))},
+		{"D:20250402091500Z", time.Date(2025, 4, 2, 9, 15, 0, 0, time.UTC)},
+		{"D:20230115", time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{"yesterday", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		if got := parsePDFDate(tt.input); !got.Equal(tt.want) {
+			t.Errorf("parsePDFDate(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestGetPDFFixture(t *testing.T) {
+	c, server := newFixtureClient(t)
+	ctx := context.Background()
+
+	doc, err := c.GetPDF(ctx, "/files/cerfa_12100-03.pdf")
+	if err != nil {
+		t.Fatalf("GetPDF() error = %v", err)
+	}
+	if doc.URL != server.URL+"/files/cerfa_12100-03.pdf" {
+		t.Errorf("URL = %q", doc.URL)
+	}
+	if doc.Title != "Cerfa 12100*03 - Demande de carte nationale d'identité" {
+		t.Errorf("Title = %q", doc.Title)
+	}
+	if len(doc.Pages) != 1 || !strings.Contains(doc.Pages[0].Text, "Formulaire cerfa n° 12100*03") {
+		t.Errorf("Pages = %+v", doc.Pages)
+	}
+
+	// Web pages are for get_article
+	if _, err := c.GetPDF(ctx, "/particuliers/vosdroits/F1342"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("GetPDF(web page) error = %v, want ErrInvalidInput", err)
+	}
+	if _, err := c.GetPDF(ctx, "/files/missing.pdf"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetPDF(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestGetImpotsPDFFixture(t *testing.T) {
+	c, _ := newFixtureImpotsClient(t)
+
+	doc, err := c.GetPDF(context.Background(), "/sites/default/files/formulaires/2042/2025/notice-2042.pdf")
+	if err != nil {
+		t.Fatalf("GetPDF() error = %v", err)
+	}
+	if len(doc.Pages) != 2 || !strings.Contains(doc.Text(), "cases 1AJ et 1BJ") {
+		t.Errorf("Text() = %q", doc.Text())
+	}
+
+	// PDFs are not articles
+	_, err = c.GetImpotsArticle(context.Background(), "/sites/default/files/formulaires/2042/2025/notice-2042.pdf")
+	if !errors.Is(err, ErrBinaryDocument) {
+		t.Errorf("GetImpotsArticle(PDF) error = %v, want ErrBinaryDocument", err)
+	}
+}
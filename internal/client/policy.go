@@ -0,0 +1,117 @@
+package client
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// urlPolicy decides which URLs a client may fetch: those of the site's hosts,
+// over the scheme and port of its base URL (https and the default port,
+// unless the base URL is overridden for tests), without credentials. It is
+// applied to the URLs given by callers and to every request sent, including
+// each redirect hop, so that no URL can reach another server.
+type urlPolicy struct {
+	scheme string
+	port   string
+	hosts  []string
+}
+
+// newURLPolicy returns the policy of s.
+func newURLPolicy(s site) urlPolicy {
+	p := urlPolicy{scheme: "https", hosts: s.hosts}
+	if u, err := url.Parse(s.baseURL); err == nil && u.Scheme != "" {
+		p.scheme = strings.ToLower(u.Scheme)
+		p.port = u.Port()
+	}
+	return p
+}
+
+// normalize returns the canonical form of u if the policy allows it: lower
+// case scheme and host, no trailing dot or default port, no fragment and no
+// dot segments in the path. Plain http links to the site are upgraded to
+// https.
+func (p urlPolicy) normalize(u *url.URL) (*url.URL, error) {
+	n := *u
+	n.Scheme = strings.ToLower(n.Scheme)
+	if n.Scheme == "http" && p.scheme == "https" && n.Port() == "" {
+		n.Scheme = "https"
+	}
+	if err := p.check(&n); err != nil {
+		return nil, err
+	}
+
+	host := canonicalHost(n.Hostname())
+	if port := n.Port(); port != "" && port != defaultPort(n.Scheme) {
+		host = net.JoinHostPort(host, port)
+	}
+	n.Host = host
+	n.Fragment, n.RawFragment = "", ""
+
+	// Resolving against itself removes "." and ".." segments
+	return n.ResolveReference(&url.URL{RawQuery: n.RawQuery, ForceQuery: n.ForceQuery}), nil
+}
+
+// check returns an ErrForbiddenDomain error if u may not be fetched.
+func (p urlPolicy) check(u *url.URL) error {
+	switch {
+	case u.Opaque != "" || u.Host == "":
+		return errorf(ErrForbiddenDomain, "URL must be absolute and hierarchical, got: %s", u.Redacted())
+	case !strings.EqualFold(u.Scheme, p.scheme):
+		return errorf(ErrForbiddenDomain, "URL must use %s, got: %s", p.scheme, u.Redacted())
+	case u.User != nil:
+		return errorf(ErrForbiddenDomain, "URL must not contain credentials, got: %s", u.Redacted())
+	}
+
+	if port := u.Port(); port != p.port && !(p.port == "" && port == defaultPort(p.scheme)) {
+		return errorf(ErrForbiddenDomain, "URL must not specify port %s, got: %s", port, u.Redacted())
+	}
+
+	host := canonicalHost(u.Hostname())
+	for _, h := range p.hosts {
+		if host == h {
+			return nil
+		}
+	}
+	if net.ParseIP(host) != nil {
+		return errorf(ErrForbiddenDomain, "URL must not use an IP address, got: %s", u.Redacted())
+	}
+	return errorf(ErrForbiddenDomain, "URL host %s is not allowed", host)
+}
+
+// canonicalHost lower cases host and strips the trailing dot of a fully
+// qualified name.
+func canonicalHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
+
+// defaultPort returns the implicit port of scheme.
+func defaultPort(scheme string) string {
+	switch strings.ToLower(scheme) {
+	case "https":
+		return "443"
+	case "http":
+		return "80"
+	}
+	return ""
+}
+
+// policyTransport is an http.RoundTripper that refuses requests that policy
+// does not allow. As redirects are followed by sending new requests, every
+// hop is checked.
+type policyTransport struct {
+	base   http.RoundTripper
+	policy urlPolicy
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *policyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.policy.check(req.URL); err != nil {
+		if req.Response != nil {
+			return nil, errorf(ErrForbiddenDomain, "redirect from %s refused: %w", req.Response.Request.URL, err)
+		}
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,195 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryConfig configures how transient failures of GET requests are retried.
+type RetryConfig struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// A value of 1 or less disables retries.
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry; it doubles with each
+	// further retry.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff. A request whose Retry-After asks for a
+	// longer delay is not retried.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryConfig is the retry policy used unless WithRetry is given.
+var DefaultRetryConfig = RetryConfig{
+	MaxAttempts: 3,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+}
+
+// retryTransport is an http.RoundTripper that retries idempotent requests
+// failing with a transient error: a 5xx or 429 status, a reset connection or
+// a timeout. Backoff is exponential with full jitter, and a Retry-After
+// header from the website takes precedence. Requests are never retried past
+// their context deadline, nor sooner than the website allows: when
+// Retry-After exceeds MaxDelay or the deadline, the failure is returned as an
+// Error carrying the delay.
+type retryTransport struct {
+	base http.RoundTripper
+	cfg  RetryConfig
+	now  func() time.Time
+	// rand returns a random duration in [0, d); it is replaced in tests.
+	rand func(d time.Duration) time.Duration
+}
+
+// newRetryTransport wraps base with the retry policy cfg.
+func newRetryTransport(base http.RoundTripper, cfg RetryConfig) *retryTransport {
+	return &retryTransport{
+		base: base,
+		cfg:  cfg,
+		now:  time.Now,
+		rand: func(d time.Duration) time.Duration {
+			if d <= 0 {
+				return 0
+			}
+			return rand.N(d)
+		},
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return t.base.RoundTrip(req)
+	}
+
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if attempt >= t.cfg.MaxAttempts || ctx.Err() != nil || !isTransient(resp, err) {
+			if attempt > 1 {
+				slog.Info("Request finished after retries", "url", req.URL.String(), "attempts", attempt, "status", statusOf(resp), "error", err)
+			}
+			return resp, err
+		}
+
+		delay, asked := t.backoff(attempt, resp)
+		deadline, hasDeadline := ctx.Deadline()
+		pastDeadline := hasDeadline && t.now().Add(delay).After(deadline)
+		if asked && (delay > t.cfg.MaxDelay || pastDeadline) {
+			slog.Warn("Not retrying request sooner than the website allows", "url", req.URL.String(), "attempts", attempt, "retry_after", delay)
+			return nil, waitError(req, resp)
+		}
+		if pastDeadline {
+			slog.Warn("Not retrying request past its deadline", "url", req.URL.String(), "attempts", attempt, "delay", delay)
+			return resp, err
+		}
+
+		slog.Warn("Retrying request after transient failure",
+			"url", req.URL.String(), "attempt", attempt, "status", statusOf(resp), "error", err, "delay", delay)
+
+		// Free the connection, and the rate limit slot, before waiting
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+			resp.Body.Close()
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// backoff returns the delay before retrying after attempt failed with resp,
+// and whether the website asked for it with Retry-After. Only the computed
+// backoff is capped by MaxDelay.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) (time.Duration, bool) {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), t.now()); ok {
+			return d, true
+		}
+	}
+
+	// Full jitter: a random delay up to the exponential bound
+	bound := t.cfg.BaseDelay << (attempt - 1)
+	if bound <= 0 || bound > t.cfg.MaxDelay {
+		bound = t.cfg.MaxDelay
+	}
+	return t.rand(bound), false
+}
+
+// waitError closes resp, whose Retry-After asks to wait longer than the
+// request may, and classifies its status so the delay reaches the caller.
+func waitError(req *http.Request, resp *http.Response) error {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	resp.Body.Close()
+	return statusError(resp.StatusCode, req.URL.String(), resp.Header)
+}
+
+// isTransient reports whether a request that returned resp and err may
+// succeed if sent again.
+func isTransient(resp *http.Response, err error) bool {
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.As(err, new(*Error)) {
+			// Cancelled by the caller, or failed for a known reason such as
+			// a page missing from the offline snapshot
+			return false
+		}
+		var netErr net.Error
+		return errors.Is(err, syscall.ECONNRESET) ||
+			errors.Is(err, syscall.ECONNREFUSED) ||
+			errors.Is(err, io.ErrUnexpectedEOF) ||
+			errors.Is(err, io.EOF) ||
+			(errors.As(err, &netErr) && netErr.Timeout())
+	}
+
+	switch resp.StatusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests,
+		http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter parses a Retry-After header, given either in seconds or as
+// an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0), true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+	return 0, false
+}
+
+// statusOf returns the status code of resp, or 0 if there is no response.
+func statusOf(resp *http.Response) int {
+	if resp == nil {
+		return 0
+	}
+	return resp.StatusCode
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,129 @@
+package client
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/temoto/robotstxt"
+)
+
+// robotsTTL is how long a host's robots.txt is trusted before it is fetched
+// again.
+const robotsTTL = 24 * time.Hour
+
+// robotsTransport is an http.RoundTripper that honours the robots.txt of
+// every host: it fetches the file on first contact, refuses the requests it
+// disallows for userAgent, and reports any Crawl-delay to onCrawlDelay.
+// A missing robots.txt (4xx) allows everything. If it cannot be fetched, the
+// previous copy is used; with none, requests fail rather than crawl blindly.
+type robotsTransport struct {
+	base         http.RoundTripper
+	userAgent    string
+	onCrawlDelay func(host string, delay time.Duration)
+	now          func() time.Time
+
+	mu    sync.Mutex
+	hosts map[string]*robotsEntry
+}
+
+// robotsEntry is the robots.txt of a single host.
+type robotsEntry struct {
+	data    *robotstxt.RobotsData
+	fetched time.Time
+}
+
+// newRobotsTransport wraps base with robots.txt checks for userAgent.
+func newRobotsTransport(base http.RoundTripper, userAgent string, onCrawlDelay func(string, time.Duration)) *robotsTransport {
+	return &robotsTransport{
+		base:         base,
+		userAgent:    userAgent,
+		onCrawlDelay: onCrawlDelay,
+		now:          time.Now,
+		hosts:        make(map[string]*robotsEntry),
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *robotsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Path == "/robots.txt" || (req.Method != http.MethodGet && req.Method != http.MethodHead) {
+		return t.base.RoundTrip(req)
+	}
+
+	robots, err := t.robots(req)
+	if err != nil {
+		return nil, err
+	}
+	if !robots.TestAgent(req.URL.RequestURI(), t.userAgent) {
+		return nil, errorf(ErrDisallowed, "robots.txt of %s does not allow fetching %s", req.URL.Host, req.URL)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// robots returns the robots.txt of the host of req, fetching it if needed.
+// Concurrent first requests to a host may fetch it more than once, which is
+// harmless.
+func (t *robotsTransport) robots(req *http.Request) (*robotstxt.RobotsData, error) {
+	host := req.URL.Host
+
+	t.mu.Lock()
+	entry := t.hosts[host]
+	t.mu.Unlock()
+	if entry != nil && t.now().Sub(entry.fetched) < robotsTTL {
+		return entry.data, nil
+	}
+
+	data, err := t.fetch(req)
+	if err != nil {
+		if entry != nil {
+			slog.Warn("Using previous robots.txt", "host", host, "error", err)
+			return entry.data, nil
+		}
+		return nil, err
+	}
+
+	t.mu.Lock()
+	t.hosts[host] = &robotsEntry{data: data, fetched: t.now()}
+	t.mu.Unlock()
+
+	if group := data.FindGroup(t.userAgent); group.CrawlDelay > 0 && t.onCrawlDelay != nil {
+		t.onCrawlDelay(host, group.CrawlDelay)
+	}
+	return data, nil
+}
+
+// fetch downloads and parses the robots.txt of the host of req.
+func (t *robotsTransport) fetch(req *http.Request) (*robotstxt.RobotsData, error) {
+	robotsURL := req.URL.Scheme + "://" + req.URL.Host + "/robots.txt"
+	robotsReq, err := http.NewRequestWithContext(req.Context(), http.MethodGet, robotsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	robotsReq.Header.Set("User-Agent", t.userAgent)
+
+	// Follow redirects, e.g. to the canonical host
+	resp, err := (&http.Client{Transport: t.base}).Do(robotsReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", robotsURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 500 {
+		return nil, statusError(resp.StatusCode, robotsURL, resp.Header)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 512<<10))
+	if err != nil {
+		return nil, errorf(ErrUpstreamUnavailable, "failed to read %s: %w", robotsURL, err)
+	}
+	data, err := robotstxt.FromStatusAndBytes(resp.StatusCode, body)
+	if err != nil {
+		// A typo in the file should not shut out the whole site; the
+		// configured delay still applies
+		slog.Warn("Ignoring invalid robots.txt", "url", robotsURL, "error", err)
+		return robotstxt.FromStatusAndBytes(http.StatusNotFound, nil)
+	}
+	return data, nil
+}
@@ -0,0 +1,165 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// robotsServer serves robots as its robots.txt, or the status robotsStatus
+// when set, and "ok" for every other page. It counts requests per kind.
+type robotsServer struct {
+	*httptest.Server
+	robots       string
+	robotsStatus atomic.Int32
+	robotsCalls  atomic.Int32
+	pageCalls    atomic.Int32
+}
+
+func newRobotsServer(t *testing.T, robots string) *robotsServer {
+	t.Helper()
+
+	s := &robotsServer{robots: robots}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/robots.txt" {
+			s.pageCalls.Add(1)
+			_, _ = io.WriteString(w, "ok")
+			return
+		}
+		s.robotsCalls.Add(1)
+		if status := s.robotsStatus.Load(); status != 0 {
+			w.WriteHeader(int(status))
+			return
+		}
+		_, _ = io.WriteString(w, s.robots)
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func robotsGet(transport http.RoundTripper, url string) error {
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func TestRobotsTransport(t *testing.T) {
+	server := newRobotsServer(t, "User-agent: *\nDisallow: /particuliers/recherche\n\nUser-agent: BadBot\nDisallow: /\n")
+	transport := newRobotsTransport(http.DefaultTransport, DefaultUserAgent, nil)
+
+	if err := robotsGet(transport, server.URL+"/particuliers/vosdroits/F1342"); err != nil {
+		t.Fatalf("allowed page error = %v", err)
+	}
+	err := robotsGet(transport, server.URL+"/particuliers/recherche?keyword=test")
+	if !errors.Is(err, ErrDisallowed) {
+		t.Errorf("disallowed page error = %v, want ErrDisallowed", err)
+	}
+
+	if server.robotsCalls.Load() != 1 {
+		t.Errorf("robots.txt fetched %d times, want once", server.robotsCalls.Load())
+	}
+	if server.pageCalls.Load() != 1 {
+		t.Errorf("website received %d page requests, want 1: disallowed pages must not be requested", server.pageCalls.Load())
+	}
+}
+
+func TestRobotsTransportUserAgentGroup(t *testing.T) {
+	server := newRobotsServer(t, "User-agent: *\nAllow: /\n\nUser-agent: VosDroits-MCP-Server\nDisallow: /private\n")
+	transport := newRobotsTransport(http.DefaultTransport, DefaultUserAgent, nil)
+
+	if err := robotsGet(transport, server.URL+"/private/page"); !errors.Is(err, ErrDisallowed) {
+		t.Errorf("error = %v, want the group of our user agent to apply", err)
+	}
+}
+
+func TestRobotsTransportCrawlDelay(t *testing.T) {
+	server := newRobotsServer(t, "User-agent: *\nCrawl-delay: 5\n")
+
+	var got time.Duration
+	transport := newRobotsTransport(http.DefaultTransport, DefaultUserAgent, func(host string, delay time.Duration) {
+		got = delay
+	})
+	if err := robotsGet(transport, server.URL+"/particuliers"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got != 5*time.Second {
+		t.Errorf("crawl delay = %v, want 5s", got)
+	}
+
+	// The polite transport only ever raises its delay
+	polite := newPoliteTransport(nil, 1, 2*time.Second)
+	polite.setCrawlDelay("example.org", 5*time.Second)
+	polite.setCrawlDelay("example.org", time.Second)
+	if d := polite.limiter("example.org").delay; d != 5*time.Second {
+		t.Errorf("delay = %v, want 5s", d)
+	}
+}
+
+func TestRobotsTransportUnavailable(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		want   error
+	}{
+		{"missing allows everything", http.StatusNotFound, nil},
+		{"server error blocks", http.StatusServiceUnavailable, ErrUpstreamUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newRobotsServer(t, "")
+			server.robotsStatus.Store(int32(tt.status))
+			transport := newRobotsTransport(http.DefaultTransport, DefaultUserAgent, nil)
+
+			err := robotsGet(transport, server.URL+"/particuliers")
+			if !errors.Is(err, tt.want) || (tt.want == nil && err != nil) {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRobotsTransportKeepsPreviousCopy(t *testing.T) {
+	server := newRobotsServer(t, "User-agent: *\nDisallow: /private\n")
+	transport := newRobotsTransport(http.DefaultTransport, DefaultUserAgent, nil)
+	now := time.Now()
+	transport.now = func() time.Time { return now }
+
+	if err := robotsGet(transport, server.URL+"/particuliers"); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	// The copy expires while robots.txt is failing
+	now = now.Add(robotsTTL + time.Minute)
+	server.robotsStatus.Store(http.StatusBadGateway)
+
+	if err := robotsGet(transport, server.URL+"/private"); !errors.Is(err, ErrDisallowed) {
+		t.Errorf("error = %v, want the previous robots.txt to apply", err)
+	}
+	if server.robotsCalls.Load() != 2 {
+		t.Errorf("robots.txt fetched %d times, want a refresh attempt", server.robotsCalls.Load())
+	}
+}
+
+func TestClientHonoursRobots(t *testing.T) {
+	server := newRobotsServer(t, "User-agent: *\nDisallow: /particuliers/vosdroits/\n")
+	c := New(5*time.Second, WithBaseURL(server.URL), fixtureCrawl)
+
+	_, err := c.GetArticle(context.Background(), server.URL+"/particuliers/vosdroits/F1342")
+	if !errors.Is(err, ErrDisallowed) {
+		t.Errorf("GetArticle() error = %v, want ErrDisallowed", err)
+	}
+	if server.pageCalls.Load() != 0 {
+		t.Errorf("website received %d page requests, want none", server.pageCalls.Load())
+	}
+}
@@ -0,0 +1,248 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// maxSearchPages bounds the number of result pages of a website fetched for
+// one page of results.
+const maxSearchPages = 10
+
+// EmptyReason explains why a search returned no results.
+type EmptyReason string
+
+const (
+	// EmptyNoMatch means the website found nothing matching the query.
+	EmptyNoMatch EmptyReason = "no_match"
+	// EmptySiteError means the search page could not be fetched.
+	EmptySiteError EmptyReason = "site_error"
+	// EmptyParseFailure means the search page was fetched but neither
+	// results nor a "no results" message could be found in it, which
+	// usually means the website layout changed.
+	EmptyParseFailure EmptyReason = "parse_failure"
+	// EmptyOffline means the client serves an offline snapshot that does
+	// not include the search page.
+	EmptyOffline EmptyReason = "offline"
+)
+
+// SearchPage is a page of search results.
+type SearchPage[T any] struct {
+	Results []T
+	// EmptyReason is set when Results is empty.
+	EmptyReason EmptyReason
+	// Err is the fetch failure behind EmptySiteError or EmptyOffline.
+	Err error
+	// Suggestions lists the alternative queries offered by the website,
+	// such as its "did you mean" spelling correction or related searches.
+	Suggestions []string
+	// Total is the number of matches reported by the website, or 0 if it
+	// does not say.
+	Total int
+	// Page is the 1-based number of this page of results.
+	Page int
+	// NextCursor resumes the search after Results. It is empty when there
+	// are no further results.
+	NextCursor string
+}
+
+// resultsPage is a page of results as served by a website.
+type resultsPage[T any] struct {
+	items []T
+	total int
+	// next is the URL of the following page, if any.
+	next string
+	// noMatch is set when the page says that nothing matched.
+	noMatch     bool
+	suggestions []string
+}
+
+// clone returns a copy of p that shares no slice with it.
+func (p *resultsPage[T]) clone() *resultsPage[T] {
+	page := *p
+	page.items = slices.Clone(p.items)
+	page.suggestions = slices.Clone(p.suggestions)
+	return &page
+}
+
+// searchCursor records where the next page of results starts: the results
+// page of the website and the number of its results already returned.
+type searchCursor struct {
+	// Search is the URL of the first results page, which identifies the
+	// query, the audience and the search endpoint of the cursor.
+	Search string `json:"q"`
+	URL    string `json:"u"`
+	Skip   int    `json:"s,omitempty"`
+	Page   int    `json:"p"`
+}
+
+func (c searchCursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor parses a cursor returned in SearchPage.NextCursor.
+func decodeCursor(s string) (searchCursor, error) {
+	var cursor searchCursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err == nil {
+		err = json.Unmarshal(data, &cursor)
+	}
+	if err != nil || cursor.URL == "" || cursor.Page < 1 || cursor.Skip < 0 {
+		return searchCursor{}, errorf(ErrInvalidInput, "invalid cursor: %q", s)
+	}
+	return cursor, nil
+}
+
+// resumeSearch decodes cursor, which must have been returned by the search
+// starting at first. Its results page must be on the host of first, with a
+// path under searchPath.
+func (f *fetcher) resumeSearch(first searchCursor, cursor, searchPath string) (searchCursor, error) {
+	start, err := decodeCursor(cursor)
+	if err != nil {
+		return searchCursor{}, err
+	}
+	if start.Search != first.URL {
+		return searchCursor{}, errorf(ErrInvalidInput, "cursor belongs to another search: pass the query and audience of the call that returned it, or no cursor to start over")
+	}
+
+	_, pageURL, err := f.resolve(start.URL)
+	if err != nil {
+		return searchCursor{}, fmt.Errorf("invalid cursor: %w", err)
+	}
+	firstURL, err := url.Parse(first.URL)
+	if err != nil || pageURL.Host != firstURL.Host || !strings.HasPrefix(pageURL.Path, searchPath) {
+		return searchCursor{}, errorf(ErrInvalidInput, "invalid cursor: %s is not a search results page", start.URL)
+	}
+	return start, nil
+}
+
+// collectResults gathers up to limit results from start onwards, following
+// the website's next-page links. If a later page fails, the results gathered
+// so far are returned with a cursor resuming at the failed page. A page
+// without results carries the reason in EmptyReason; only cancellation of
+// ctx is returned as an error.
+func collectResults[T any](ctx context.Context, start searchCursor, limit int, fetch func(ctx context.Context, pageURL string) (*resultsPage[T], error)) (*SearchPage[T], error) {
+	out := &SearchPage[T]{Page: start.Page}
+	resume := func(pageURL string, skip int) string {
+		return searchCursor{Search: start.Search, URL: pageURL, Skip: skip, Page: start.Page + 1}.encode()
+	}
+
+	// listed and noMatch record whether any page listed results or said
+	// that none matched, to tell an empty search from a broken layout.
+	var listed, noMatch bool
+
+	pageURL, skip := start.URL, start.Skip
+	for range maxSearchPages {
+		page, err := fetch(ctx, pageURL)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			if len(out.Results) == 0 {
+				out.EmptyReason, out.Err = EmptySiteError, err
+				return out, nil
+			}
+			out.NextCursor = resume(pageURL, skip)
+			return out, nil
+		}
+
+		out.Total = max(out.Total, page.total)
+		out.Suggestions = appendUnique(out.Suggestions, page.suggestions...)
+		listed = listed || len(page.items) > 0
+		noMatch = noMatch || page.noMatch
+
+		items := page.items[min(skip, len(page.items)):]
+		n := min(limit-len(out.Results), len(items))
+		out.Results = append(out.Results, items[:n]...)
+
+		switch {
+		case n < len(items):
+			out.NextCursor = resume(pageURL, skip+n)
+			return out, nil
+		case page.next == "":
+			return out.classify(listed, noMatch), nil
+		case len(out.Results) == limit:
+			out.NextCursor = resume(page.next, 0)
+			return out, nil
+		}
+		pageURL, skip = page.next, 0
+	}
+
+	out.NextCursor = resume(pageURL, skip)
+	return out.classify(listed, noMatch), nil
+}
+
+// offlineResults reports a search whose page is missing from the offline
+// snapshot served by f as EmptyOffline: the website is not down, searches
+// are only available offline when they were recorded.
+func offlineResults[T any](f *fetcher, page *SearchPage[T]) *SearchPage[T] {
+	if f.snapshot != nil && page.EmptyReason == EmptySiteError && errors.Is(page.Err, ErrNotFound) {
+		page.EmptyReason = EmptyOffline
+		page.Err = errorf(ErrNotFound, "search unavailable offline: the snapshot taken on %s does not include this search", f.snapshot.CreatedAt.Format("2006-01-02"))
+	}
+	return page
+}
+
+// classify sets EmptyReason if p has no results. Running out of results after
+// the website listed some also counts as no match.
+func (p *SearchPage[T]) classify(listed, noMatch bool) *SearchPage[T] {
+	switch {
+	case len(p.Results) > 0:
+	case listed || noMatch:
+		p.EmptyReason = EmptyNoMatch
+	default:
+		p.EmptyReason = EmptyParseFailure
+	}
+	return p
+}
+
+// appendUnique appends the non-empty values missing from list.
+func appendUnique(list []string, values ...string) []string {
+	for _, v := range values {
+		v = strings.Join(strings.Fields(v), " ")
+		if v != "" && !slices.Contains(list, v) {
+			list = append(list, v)
+		}
+	}
+	return list
+}
+
+// noResultsPattern matches the messages shown when a search finds nothing.
+var noResultsPattern = regexp.MustCompile(`(?i)\b(aucun(e)? (résultat|document|contenu)|0 résultat|pas de résultat)`)
+
+// isNoResultsText reports whether text says that the search found nothing.
+func isNoResultsText(text string) bool {
+	return noResultsPattern.MatchString(text)
+}
+
+// suggestionSelector matches the links of the websites' "did you mean" and
+// related searches blocks.
+const suggestionSelector = "[class*='suggestion'] a, [class*='did-you-mean'] a, [class*='spelling'] a, [class*='related-search'] a, [class*='recherches-associees'] a"
+
+// resultCountPattern matches result counts such as "1 234 résultats".
+var resultCountPattern = regexp.MustCompile(`(\d[\d\s\x{00a0}\x{202f}.]*)\s*résultats?`)
+
+// parseResultCount returns the number of results announced in text.
+func parseResultCount(text string) (int, bool) {
+	m := resultCountPattern.FindStringSubmatch(text)
+	if m == nil {
+		return 0, false
+	}
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, m[1])
+	n, err := strconv.Atoi(digits)
+	return n, err == nil
+}
@@ -0,0 +1,308 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSearchCursorRoundTrip(t *testing.T) {
+	want := searchCursor{URL: "https://example.com/recherche?page=2", Skip: 3, Page: 4}
+
+	got, err := decodeCursor(want.encode())
+	if err != nil {
+		t.Fatalf("decodeCursor() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("decodeCursor() = %+v, want %+v", got, want)
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	for _, cursor := range []string{
+		"not base64!",
+		"bm90IGpzb24",
+		searchCursor{Page: 1}.encode(),
+		searchCursor{URL: "https://example.com"}.encode(),
+		searchCursor{URL: "https://example.com", Page: 1, Skip: -1}.encode(),
+	} {
+		if _, err := decodeCursor(cursor); err == nil {
+			t.Errorf("decodeCursor(%q) error = nil, want error", cursor)
+		}
+	}
+}
+
+// fakePages returns a fetch function serving n pages of size results each,
+// named "p<page>r<result>", with URLs "page1" to "page<n>".
+func fakePages(n, size int, fail map[string]bool) func(context.Context, string) (*resultsPage[string], error) {
+	return func(_ context.Context, pageURL string) (*resultsPage[string], error) {
+		if fail[pageURL] {
+			return nil, errors.New("fetch failed")
+		}
+		var p int
+		if _, err := fmt.Sscanf(pageURL, "page%d", &p); err != nil || p < 1 || p > n {
+			return nil, fmt.Errorf("unknown page %q", pageURL)
+		}
+		page := &resultsPage[string]{total: n * size}
+		for i := 1; i <= size; i++ {
+			page.items = append(page.items, fmt.Sprintf("p%dr%d", p, i))
+		}
+		if p < n {
+			page.next = fmt.Sprintf("page%d", p+1)
+		}
+		return page, nil
+	}
+}
+
+func TestCollectResults(t *testing.T) {
+	ctx := context.Background()
+	fetch := fakePages(3, 4, nil)
+
+	// The first page of 6 results spans two website pages
+	first, err := collectResults(ctx, searchCursor{URL: "page1", Page: 1}, 6, fetch)
+	if err != nil {
+		t.Fatalf("collectResults() error = %v", err)
+	}
+	if len(first.Results) != 6 || first.Results[5] != "p2r2" {
+		t.Fatalf("first page = %v", first.Results)
+	}
+	if first.Total != 12 || first.Page != 1 || first.NextCursor == "" {
+		t.Errorf("first page Total = %d, Page = %d, NextCursor = %q", first.Total, first.Page, first.NextCursor)
+	}
+
+	// The cursor resumes in the middle of a website page
+	cursor, err := decodeCursor(first.NextCursor)
+	if err != nil {
+		t.Fatalf("decodeCursor() error = %v", err)
+	}
+	second, err := collectResults(ctx, cursor, 6, fetch)
+	if err != nil {
+		t.Fatalf("collectResults() error = %v", err)
+	}
+	want := []string{"p2r3", "p2r4", "p3r1", "p3r2", "p3r3", "p3r4"}
+	if fmt.Sprint(second.Results) != fmt.Sprint(want) {
+		t.Errorf("second page = %v, want %v", second.Results, want)
+	}
+	if second.Page != 2 || second.NextCursor != "" {
+		t.Errorf("second page Page = %d, NextCursor = %q, want last page", second.Page, second.NextCursor)
+	}
+}
+
+func TestCollectResultsExactPage(t *testing.T) {
+	page, err := collectResults(context.Background(), searchCursor{URL: "page1", Page: 1}, 4, fakePages(2, 4, nil))
+	if err != nil {
+		t.Fatalf("collectResults() error = %v", err)
+	}
+
+	cursor, err := decodeCursor(page.NextCursor)
+	if err != nil {
+		t.Fatalf("decodeCursor() error = %v", err)
+	}
+	if cursor.URL != "page2" || cursor.Skip != 0 {
+		t.Errorf("cursor = %+v, want start of page2", cursor)
+	}
+}
+
+func TestCollectResultsPartialFailure(t *testing.T) {
+	ctx := context.Background()
+	fetch := fakePages(3, 2, map[string]bool{"page2": true})
+
+	page, err := collectResults(ctx, searchCursor{URL: "page1", Page: 1}, 5, fetch)
+	if err != nil {
+		t.Fatalf("collectResults() error = %v", err)
+	}
+	if len(page.Results) != 2 {
+		t.Errorf("got %d results, want the 2 of the first page", len(page.Results))
+	}
+
+	// The cursor retries the page that failed
+	cursor, err := decodeCursor(page.NextCursor)
+	if err != nil {
+		t.Fatalf("decodeCursor() error = %v", err)
+	}
+	if cursor.URL != "page2" {
+		t.Errorf("cursor URL = %q, want page2", cursor.URL)
+	}
+
+	failed, err := collectResults(ctx, cursor, 5, fetch)
+	if err != nil {
+		t.Fatalf("collectResults() from a failing page error = %v", err)
+	}
+	if failed.EmptyReason != EmptySiteError || failed.Err == nil {
+		t.Errorf("failing page = %+v, want %q with error", failed, EmptySiteError)
+	}
+}
+
+func TestCollectResultsEmptyReason(t *testing.T) {
+	tests := []struct {
+		name string
+		page resultsPage[string]
+		want EmptyReason
+	}{
+		{"no match", resultsPage[string]{noMatch: true}, EmptyNoMatch},
+		{"unparsed", resultsPage[string]{}, EmptyParseFailure},
+		{"results", resultsPage[string]{items: []string{"a"}}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetch := func(context.Context, string) (*resultsPage[string], error) {
+				return &tt.page, nil
+			}
+			page, err := collectResults(context.Background(), searchCursor{URL: "page1", Page: 1}, 5, fetch)
+			if err != nil {
+				t.Fatalf("collectResults() error = %v", err)
+			}
+			if page.EmptyReason != tt.want {
+				t.Errorf("EmptyReason = %q, want %q", page.EmptyReason, tt.want)
+			}
+		})
+	}
+}
+
+func TestCollectResultsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	fetch := func(ctx context.Context, _ string) (*resultsPage[string], error) {
+		return nil, ctx.Err()
+	}
+	if _, err := collectResults(ctx, searchCursor{URL: "page1", Page: 1}, 5, fetch); !errors.Is(err, context.Canceled) {
+		t.Errorf("collectResults() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestIsNoResultsText(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"Aucun résultat ne correspond à votre recherche.", true},
+		{"0 résultat", true},
+		{"Il n'y a pas de résultat pour cette recherche", true},
+		{"10 résultats", false},
+		{"Résultats de recherche", false},
+	}
+
+	for _, tt := range tests {
+		if got := isNoResultsText(tt.text); got != tt.want {
+			t.Errorf("isNoResultsText(%q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestParseResultCount(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+		ok   bool
+	}{
+		{"5 résultats", 5, true},
+		{"1 résultat", 1, true},
+		{"Environ 1 234 résultats pour « carte »", 1234, true},
+		{"Résultats de recherche", 0, false},
+		{"", 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseResultCount(tt.text)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseResultCount(%q) = %d, %v, want %d, %v", tt.text, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestSearchProceduresPageFixture(t *testing.T) {
+	c, server := newFixtureClient(t)
+	ctx := context.Background()
+
+	first, err := c.SearchProceduresPage(ctx, "carte identité", 2, "")
+	if err != nil {
+		t.Fatalf("SearchProceduresPage() error = %v", err)
+	}
+	if len(first.Results) != 2 || first.Total != 5 || first.Page != 1 || first.NextCursor == "" {
+		t.Fatalf("first page = %+v", first)
+	}
+
+	second, err := c.SearchProceduresPage(ctx, "carte identité", 2, first.NextCursor)
+	if err != nil {
+		t.Fatalf("SearchProceduresPage() with cursor error = %v", err)
+	}
+	if len(second.Results) != 2 || second.Page != 2 {
+		t.Fatalf("second page = %+v", second)
+	}
+	if second.Results[0].URL != "https://www.service-public.gouv.fr/particuliers/vosdroits/F21089" {
+		t.Errorf("second page starts at %q, want the third result", second.Results[0].URL)
+	}
+	if second.Results[1].URL != server.URL+"/particuliers/vosdroits/F1359" {
+		t.Errorf("second page ends at %q, want the first result of page 2", second.Results[1].URL)
+	}
+
+	third, err := c.SearchProceduresPage(ctx, "carte identité", 2, second.NextCursor)
+	if err != nil {
+		t.Fatalf("SearchProceduresPage() with cursor error = %v", err)
+	}
+	if len(third.Results) != 1 || third.Page != 3 || third.NextCursor != "" {
+		t.Errorf("third page = %+v, want the last result", third)
+	}
+}
+
+func TestSearchProceduresPageInvalidCursor(t *testing.T) {
+	c, server := newFixtureClient(t)
+	ctx := context.Background()
+
+	first, err := c.SearchProceduresPage(ctx, "carte identité", 2, "")
+	if err != nil || first.NextCursor == "" {
+		t.Fatalf("SearchProceduresPage() = %+v, %v", first, err)
+	}
+	searchURL := server.URL + "/particuliers/recherche?keyword=carte+identit%C3%A9"
+
+	tests := []struct {
+		name     string
+		query    string
+		audience Audience
+		cursor   string
+	}{
+		{"garbage", "carte identité", AudienceParticuliers, "garbage"},
+		{"foreign host", "carte identité", AudienceParticuliers, searchCursor{Search: searchURL, URL: "https://example.com/recherche", Page: 2}.encode()},
+		{"other query", "passeport", AudienceParticuliers, first.NextCursor},
+		{"other audience", "carte identité", AudienceAssociations, first.NextCursor},
+		{"not a search page", "carte identité", AudienceParticuliers, searchCursor{Search: searchURL, URL: server.URL + "/particuliers/vosdroits/F1342", Page: 2}.encode()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.ForAudience(tt.audience).SearchProceduresPage(ctx, tt.query, 2, tt.cursor)
+			if !errors.Is(err, ErrInvalidInput) && !errors.Is(err, ErrForbiddenDomain) {
+				t.Errorf("SearchProceduresPage() error = %v, want an invalid cursor", err)
+			}
+		})
+	}
+}
+
+func TestSearchImpotsPageFixture(t *testing.T) {
+	c, _ := newFixtureImpotsClient(t)
+	ctx := context.Background()
+
+	first, err := c.SearchImpotsPage(ctx, "formulaire 2042", 2, "")
+	if err != nil {
+		t.Fatalf("SearchImpotsPage() error = %v", err)
+	}
+	if len(first.Results) != 2 || first.Total != 3 || first.NextCursor == "" {
+		t.Fatalf("first page = %+v", first)
+	}
+
+	second, err := c.SearchImpotsPage(ctx, "formulaire 2042", 2, first.NextCursor)
+	if err != nil {
+		t.Fatalf("SearchImpotsPage() with cursor error = %v", err)
+	}
+	if len(second.Results) != 1 || second.Page != 2 || second.NextCursor != "" {
+		t.Errorf("second page = %+v, want the last result", second)
+	}
+
+	// Cursors only resume the search that returned them
+	if _, err := c.SearchImpotsPage(ctx, "PEA", 2, first.NextCursor); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("SearchImpotsPage(other query) error = %v, want ErrInvalidInput", err)
+	}
+}
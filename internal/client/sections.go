@@ -0,0 +1,95 @@
+package client
+
+import (
+	"strings"
+
+	"github.com/gocolly/colly/v2"
+)
+
+// ArticleSection is a heading of an article together with the content that
+// follows it, up to the next heading of the same or a higher level.
+type ArticleSection struct {
+	// Level is the heading level: 2 for h2, 3 for h3 and so on.
+	Level      int
+	Title      string
+	Paragraphs []string
+	// Lists holds the bullet and numbered lists of the section, one slice of
+	// items per list.
+	Lists    [][]string
+	Sections []ArticleSection
+}
+
+// parseSections walks the elements of e matching selector in document order
+// and groups paragraphs and lists under the heading that precedes them.
+// Content found before the first heading is ignored, as is text rejected by
+// keep.
+func parseSections(e *colly.HTMLElement, selector string, keep func(text string) bool) []ArticleSection {
+	var flat []ArticleSection
+
+	e.ForEach(selector, func(_ int, elem *colly.HTMLElement) {
+		// Paragraphs and nested lists inside a list item belong to that item
+		if elem.DOM.Parent().Closest("li").Length() > 0 {
+			return
+		}
+
+		if level := headingLevel(elem.Name); level > 0 {
+			title := strings.TrimSpace(elem.Text)
+			if title != "" && keep(title) {
+				flat = append(flat, ArticleSection{Level: level, Title: title})
+			}
+			return
+		}
+
+		if len(flat) == 0 {
+			return
+		}
+		current := &flat[len(flat)-1]
+
+		switch elem.Name {
+		case "ul", "ol":
+			var items []string
+			elem.ForEach("li", func(_ int, li *colly.HTMLElement) {
+				if li.DOM.Parent().Closest("li").Length() > 0 {
+					return
+				}
+				if text := strings.Join(strings.Fields(li.Text), " "); text != "" && keep(text) {
+					items = append(items, text)
+				}
+			})
+			if len(items) > 0 {
+				current.Lists = append(current.Lists, items)
+			}
+		default:
+			if text := strings.TrimSpace(elem.Text); text != "" && keep(text) {
+				current.Paragraphs = append(current.Paragraphs, text)
+			}
+		}
+	})
+
+	return nestSections(flat)
+}
+
+// nestSections turns a flat, document-ordered list of sections into a tree
+// in which each section contains the following sections of a deeper level.
+func nestSections(flat []ArticleSection) []ArticleSection {
+	var nested []ArticleSection
+	for i := 0; i < len(flat); {
+		section := flat[i]
+		end := i + 1
+		for end < len(flat) && flat[end].Level > section.Level {
+			end++
+		}
+		section.Sections = nestSections(flat[i+1 : end])
+		nested = append(nested, section)
+		i = end
+	}
+	return nested
+}
+
+// headingLevel returns the level of an h1-h6 tag name, or 0 for other tags.
+func headingLevel(tag string) int {
+	if len(tag) == 2 && tag[0] == 'h' && tag[1] >= '1' && tag[1] <= '6' {
+		return int(tag[1] - '0')
+	}
+	return 0
+}
@@ -0,0 +1,190 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// Snapshot is an archive of fetched pages. A snapshot recorded while online
+// lets the clients serve every tool from local data, for example in
+// air-gapped demo environments.
+type Snapshot struct {
+	CreatedAt time.Time               `json:"created_at"`
+	Pages     map[string]SnapshotPage `json:"pages"`
+
+	mu sync.RWMutex
+}
+
+// SnapshotPage is a single page stored in a Snapshot. Redirects are stored
+// with their status code and target so that they replay offline.
+type SnapshotPage struct {
+	StatusCode  int    `json:"status,omitempty"`
+	Location    string `json:"location,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// NewSnapshot returns an empty snapshot dated now.
+func NewSnapshot() *Snapshot {
+	return &Snapshot{
+		CreatedAt: time.Now().UTC(),
+		Pages:     make(map[string]SnapshotPage),
+	}
+}
+
+// LoadSnapshot reads a snapshot archive written by Save.
+func LoadSnapshot(path string) (*Snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot: %w", err)
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot %s: %w", path, err)
+	}
+	defer zr.Close()
+
+	var snap Snapshot
+	if err := json.NewDecoder(zr).Decode(&snap); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot %s: %w", path, err)
+	}
+	if snap.Pages == nil {
+		snap.Pages = make(map[string]SnapshotPage)
+	}
+
+	return &snap, nil
+}
+
+// Save writes the snapshot to path as gzip-compressed JSON.
+func (s *Snapshot) Save(path string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(s); err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to compress snapshot: %w", err)
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// Len returns the number of pages in the snapshot.
+func (s *Snapshot) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.Pages)
+}
+
+func (s *Snapshot) page(pageURL string) (SnapshotPage, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	page, ok := s.Pages[pageURL]
+	return page, ok
+}
+
+func (s *Snapshot) add(pageURL string, page SnapshotPage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Pages[pageURL] = page
+}
+
+// snapshotTransport is an http.RoundTripper that serves pages exclusively
+// from a snapshot and never contacts the network.
+type snapshotTransport struct {
+	snapshot *Snapshot
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *snapshotTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+
+	page, ok := t.snapshot.page(req.URL.String())
+	if !ok || req.Method != http.MethodGet {
+		return nil, errorf(ErrNotFound, "page not available in offline snapshot: %s", req.URL)
+	}
+
+	status := page.StatusCode
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	header := make(http.Header)
+	if page.ContentType != "" {
+		header.Set("Content-Type", page.ContentType)
+	}
+	if page.Location != "" {
+		header.Set("Location", page.Location)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(page.Body)),
+		ContentLength: int64(len(page.Body)),
+		Request:       req,
+	}, nil
+}
+
+// recordingTransport is an http.RoundTripper that copies every successful
+// GET response into a snapshot.
+type recordingTransport struct {
+	base     http.RoundTripper
+	snapshot *Snapshot
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || req.Method != http.MethodGet {
+		return resp, err
+	}
+
+	// Keep redirects so the original URL still resolves offline
+	if location := resp.Header.Get("Location"); location != "" && resp.StatusCode >= 300 && resp.StatusCode < 400 {
+		t.snapshot.add(req.URL.String(), SnapshotPage{
+			StatusCode: resp.StatusCode,
+			Location:   location,
+		})
+		return resp, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	t.snapshot.add(req.URL.String(), SnapshotPage{
+		ContentType: resp.Header.Get("Content-Type"),
+		Body:        body,
+	})
+
+	return resp, nil
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSnapshotRecordAndReplay(t *testing.T) {
+	server := newFixtureServer(t, servicePublicPages)
+	ctx := context.Background()
+
+	// Record the pages while the site is reachable
+	snap := NewSnapshot()
+	recorder := New(5*time.Second, WithBaseURL(server.URL), WithSnapshotRecorder(snap))
+
+	want, err := recorder.GetArticle(ctx, "/particuliers/vosdroits/F1342")
+	if err != nil {
+		t.Fatalf("GetArticle() while recording error = %v", err)
+	}
+	if _, err := recorder.ListLifeEvents(ctx); err != nil {
+		t.Fatalf("ListLifeEvents() while recording error = %v", err)
+	}
+	if snap.Len() != 2 {
+		t.Errorf("snapshot has %d pages, want 2", snap.Len())
+	}
+
+	path := filepath.Join(t.TempDir(), "snapshot.json.gz")
+	if err := snap.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	// Replay with the site down
+	server.Close()
+
+	loaded, err := LoadSnapshot(path)
+	if err != nil {
+		t.Fatalf("LoadSnapshot() error = %v", err)
+	}
+	if !loaded.CreatedAt.Equal(snap.CreatedAt) {
+		t.Errorf("CreatedAt = %v, want %v", loaded.CreatedAt, snap.CreatedAt)
+	}
+
+	offline := New(5*time.Second, WithBaseURL(server.URL), WithSnapshot(loaded))
+	if !offline.SnapshotDate().Equal(snap.CreatedAt) {
+		t.Errorf("SnapshotDate() = %v, want %v", offline.SnapshotDate(), snap.CreatedAt)
+	}
+
+	got, err := offline.GetArticle(ctx, "/particuliers/vosdroits/F1342")
+	if err != nil {
+		t.Fatalf("GetArticle() from snapshot error = %v", err)
+	}
+	if got.Title != want.Title || got.Content != want.Content {
+		t.Errorf("GetArticle() from snapshot = %+v, want %+v", got, want)
+	}
+
+	// Pages that were not recorded are unavailable
+	_, err = offline.GetArticle(ctx, "/particuliers/vosdroits/F16225")
+	if err == nil || !strings.Contains(err.Error(), "offline snapshot") {
+		t.Errorf("GetArticle() for missing page error = %v, want offline snapshot error", err)
+	}
+}
+
+func TestSnapshotSearch(t *testing.T) {
+	server := newFixtureServer(t, servicePublicPages)
+	ctx := context.Background()
+
+	snap := NewSnapshot()
+	recorder := New(5*time.Second, WithBaseURL(server.URL), WithSnapshotRecorder(snap))
+	want, err := recorder.SearchProcedures(ctx, "carte identité", 10)
+	if err != nil {
+		t.Fatalf("SearchProcedures() while recording error = %v", err)
+	}
+	server.Close()
+
+	// Recorded searches replay offline
+	offline := New(5*time.Second, WithBaseURL(server.URL), WithSnapshot(snap))
+	got, err := offline.SearchProcedures(ctx, "carte identité", 10)
+	if err != nil || len(got) != len(want) {
+		t.Fatalf("SearchProcedures() from snapshot = %d results, %v; want %d", len(got), err, len(want))
+	}
+
+	// Other searches are reported as unavailable offline, not as a site error
+	page, err := offline.SearchProceduresPage(ctx, "passeport", 10, "")
+	if err != nil {
+		t.Fatalf("SearchProceduresPage() error = %v", err)
+	}
+	if page.EmptyReason != EmptyOffline || !errors.Is(page.Err, ErrNotFound) || !strings.Contains(page.Err.Error(), "unavailable offline") {
+		t.Errorf("SearchProceduresPage() = %q, %v; want %q", page.EmptyReason, page.Err, EmptyOffline)
+	}
+	if _, err := offline.SearchProcedures(ctx, "passeport", 10); !errors.Is(err, ErrNotFound) {
+		t.Errorf("SearchProcedures() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestSnapshotDateLive(t *testing.T) {
+	c, _ := newFixtureClient(t)
+	if !c.SnapshotDate().IsZero() {
+		t.Errorf("SnapshotDate() = %v, want zero time when fetching live", c.SnapshotDate())
+	}
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+// Source is a government website that can be searched and browsed. Both
+// Client (service-public.gouv.fr) and ImpotsClient (impots.gouv.fr) implement
+// it on top of the shared fetch pipeline, so supporting another site only
+// requires its URLs and selectors. Callers given a URL pick the Source that
+// owns it.
+type Source interface {
+	// Name returns the canonical domain of the website.
+	Name() string
+	// Owns reports whether rawURL is an absolute URL on the website.
+	Owns(rawURL string) bool
+	// SnapshotDate returns when the offline snapshot being served was taken,
+	// or the zero time when pages are fetched live.
+	SnapshotDate() time.Time
+	// Degraded reports whether the website is failing and results come from
+	// the cache.
+	Degraded() bool
+	// Search returns up to limit documents matching query.
+	Search(ctx context.Context, query string, limit int) ([]Result, error)
+	// GetDocument retrieves the document at documentURL.
+	GetDocument(ctx context.Context, documentURL string) (*Document, error)
+	// GetPDF retrieves the PDF document at documentURL.
+	GetPDF(ctx context.Context, documentURL string) (*PDFDocument, error)
+	// Categories returns the top-level categories of the website.
+	Categories(ctx context.Context) ([]Category, error)
+}
+
+// Result is a search result returned by a Source.
+type Result struct {
+	Title       string
+	URL         string
+	Description string
+	Type        string
+	Date        string
+}
+
+// Document is a page retrieved from a Source.
+type Document struct {
+	Title       string
+	URL         string
+	Description string
+	Type        string
+	Content     string
+}
+
+// Category is a top-level category of a Source.
+type Category struct {
+	Name        string
+	Description string
+	URL         string
+}
+
+var (
+	_ Source = (*Client)(nil)
+	_ Source = (*ImpotsClient)(nil)
+)
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"strings"
+
+	"github.com/gocolly/colly/v2"
+	"golang.org/x/net/html"
+)
+
+// Table is an HTML table of a page, such as a list of fees, income
+// thresholds or deadlines.
+type Table struct {
+	Caption string
+	// Headers holds the column headings, taken from the first row when it is
+	// in a thead or made only of th cells. It is empty otherwise.
+	Headers []string
+	Rows    [][]string
+}
+
+// parseTables extracts the tables of e matching selector. Tables without any
+// cell are skipped.
+func parseTables(e *colly.HTMLElement, selector string) []Table {
+	var tables []Table
+	e.ForEach(selector, func(_ int, elem *colly.HTMLElement) {
+		table := extractTable(elem.DOM.Get(0), plainText)
+		if len(table.Headers) > 0 || len(table.Rows) > 0 {
+			tables = append(tables, table)
+		}
+	})
+	return tables
+}
+
+// extractTable reads the caption, headers and rows of the table n, using
+// text to render the content of each cell. Nested tables are ignored.
+func extractTable(n *html.Node, text func(*html.Node) string) Table {
+	var table Table
+
+	var walk func(node *html.Node, inHead bool)
+	walk = func(node *html.Node, inHead bool) {
+		for c := node.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode {
+				continue
+			}
+			switch c.Data {
+			case "caption":
+				table.Caption = text(c)
+			case "thead":
+				walk(c, true)
+			case "tr":
+				var row []string
+				onlyHeaders := true
+				for cell := c.FirstChild; cell != nil; cell = cell.NextSibling {
+					if cell.Type == html.ElementNode && (cell.Data == "th" || cell.Data == "td") {
+						row = append(row, text(cell))
+						onlyHeaders = onlyHeaders && cell.Data == "th"
+					}
+				}
+				if len(row) == 0 {
+					continue
+				}
+				if table.Headers == nil && len(table.Rows) == 0 && (inHead || onlyHeaders) {
+					table.Headers = row
+					continue
+				}
+				table.Rows = append(table.Rows, row)
+			case "table":
+				// Nested tables are not part of this table
+			default:
+				walk(c, inHead)
+			}
+		}
+	}
+	walk(n, false)
+
+	return table
+}
+
+// plainText returns the text of n with whitespace collapsed.
+func plainText(n *html.Node) string {
+	var b strings.Builder
+	var walk func(*html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.TextNode {
+			b.WriteString(node.Data)
+			b.WriteString(" ")
+		}
+		for c := node.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return strings.Join(strings.Fields(b.String()), " ")
+}
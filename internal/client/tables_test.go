@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestExtractTable(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  Table
+	}{
+		{
+			name:  "thead and caption",
+			input: `<table><caption> Tarifs </caption><thead><tr><td>Situation</td><td>Coût</td></tr></thead><tbody><tr><td>Perte</td><td>25 €</td></tr></tbody></table>`,
+			want:  Table{Caption: "Tarifs", Headers: []string{"Situation", "Coût"}, Rows: [][]string{{"Perte", "25 €"}}},
+		},
+		{
+			name:  "header row of th cells",
+			input: `<table><tr><th>Âge</th><th>Montant</th></tr><tr><th>Moins de 18 ans</th><td>Gratuit</td></tr></table>`,
+			want:  Table{Headers: []string{"Âge", "Montant"}, Rows: [][]string{{"Moins de 18 ans", "Gratuit"}}},
+		},
+		{
+			name:  "no headers",
+			input: `<table><tr><td>A</td><td><p>B <strong>bis</strong></p></td></tr><tr><td>C</td></tr></table>`,
+			want:  Table{Rows: [][]string{{"A", "B bis"}, {"C"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := html.Parse(strings.NewReader("<html><body>" + tt.input + "</body></html>"))
+			if err != nil {
+				t.Fatalf("html.Parse() error = %v", err)
+			}
+			table := doc.FirstChild.LastChild.FirstChild
+
+			if got := extractTable(table, plainText); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("extractTable() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTablesFixture(t *testing.T) {
+	ctx := context.Background()
+	c, _ := newFixtureClient(t)
+
+	article, err := c.GetArticle(ctx, "/particuliers/vosdroits/F1342")
+	if err != nil {
+		t.Fatalf("GetArticle() error = %v", err)
+	}
+	want := []Table{{
+		Caption: "Tarifs",
+		Headers: []string{"Situation", "Coût"},
+		Rows:    [][]string{{"Première demande", "Gratuit"}, {"Perte ou vol", "25 €"}},
+	}}
+	if !reflect.DeepEqual(article.Tables, want) {
+		t.Errorf("article Tables = %+v, want %+v", article.Tables, want)
+	}
+
+	details, err := c.GetLifeEventDetails(ctx, "/particuliers/vosdroits/F16225")
+	if err != nil {
+		t.Fatalf("GetLifeEventDetails() error = %v", err)
+	}
+	if tables := details.Sections[0].Tables; len(tables) != 1 || tables[0].Rows[0][1] != "11e à 13e semaine" {
+		t.Errorf("life event section Tables = %+v", tables)
+	}
+	if tables := details.Sections[1].Tables; len(tables) != 0 {
+		t.Errorf("section without tables has Tables = %+v", tables)
+	}
+
+	impotsClient, _ := newFixtureImpotsClient(t)
+	impotsArticle, err := impotsClient.GetImpotsArticle(ctx, "/formulaire/2042/declaration-des-revenus")
+	if err != nil {
+		t.Fatalf("GetImpotsArticle() error = %v", err)
+	}
+	if len(impotsArticle.Tables) != 1 || impotsArticle.Tables[0].Caption != "Barème 2025" || len(impotsArticle.Tables[0].Rows) != 2 {
+		t.Errorf("impots article Tables = %+v", impotsArticle.Tables)
+	}
+}
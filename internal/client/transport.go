@@ -0,0 +1,167 @@
+package client
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CrawlConfig configures how politely the websites are crawled.
+type CrawlConfig struct {
+	// UserAgent identifies the server to the websites. It should give a way
+	// to contact its operator; DefaultUserAgent is used when empty.
+	UserAgent string
+	// Delay is the minimum time between requests to a host. A longer
+	// Crawl-delay in the host's robots.txt takes precedence.
+	Delay time.Duration
+	// Parallelism is the number of concurrent requests allowed per host.
+	Parallelism int
+	// IgnoreRobots disables robots.txt checks.
+	IgnoreRobots bool
+}
+
+// DefaultUserAgent identifies the server and links to its project page.
+const DefaultUserAgent = "VosDroits-MCP-Server/1.0 (+https://github.com/guigui42/mcp-vosdroits)"
+
+// DefaultCrawlConfig is the crawl policy used unless WithCrawl is given.
+var DefaultCrawlConfig = CrawlConfig{
+	UserAgent:   DefaultUserAgent,
+	Delay:       time.Second,
+	Parallelism: 1,
+}
+
+// politeTransport is an http.RoundTripper that rate limits requests per host.
+// Unlike colly's LimitRule, waiting for a slot or for the inter-request delay
+// honours the request context, so a cancelled call returns immediately and
+// releases its slot instead of blocking other callers.
+type politeTransport struct {
+	base        http.RoundTripper
+	parallelism int
+	delay       time.Duration
+
+	mu    sync.Mutex
+	hosts map[string]*hostLimiter
+}
+
+// hostLimiter tracks concurrency and request spacing for a single host.
+type hostLimiter struct {
+	slots chan struct{}
+
+	mu    sync.Mutex
+	delay time.Duration
+	next  time.Time
+}
+
+// newPoliteTransport wraps base with per-host rate limiting.
+func newPoliteTransport(base http.RoundTripper, parallelism int, delay time.Duration) *politeTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	return &politeTransport{
+		base:        base,
+		parallelism: parallelism,
+		delay:       delay,
+		hosts:       make(map[string]*hostLimiter),
+	}
+}
+
+func (t *politeTransport) limiter(host string) *hostLimiter {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	l, ok := t.hosts[host]
+	if !ok {
+		l = &hostLimiter{slots: make(chan struct{}, t.parallelism), delay: t.delay}
+		t.hosts[host] = l
+	}
+	return l
+}
+
+// setCrawlDelay raises the delay between requests to host to delay, as asked
+// by its robots.txt. It never lowers the configured delay.
+func (t *politeTransport) setCrawlDelay(host string, delay time.Duration) {
+	l := t.limiter(host)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if delay > l.delay {
+		slog.Info("Using crawl delay from robots.txt", "host", host, "delay", delay)
+		l.delay = delay
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *politeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	l := t.limiter(req.URL.Host)
+
+	// Acquire a slot for this host
+	select {
+	case l.slots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	release := func() {
+		l.mu.Lock()
+		l.next = time.Now().Add(l.delay)
+		l.mu.Unlock()
+		<-l.slots
+	}
+
+	// Respect the delay since the previous request to this host finished
+	if err := l.wait(ctx); err != nil {
+		<-l.slots
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	// Keep the slot until the body has been consumed
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// wait blocks until the host may be contacted again or ctx is done.
+func (l *hostLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	d := time.Until(l.next)
+	l.mu.Unlock()
+
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// releasingBody releases the host slot exactly once when the body is closed.
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+	return err
+}
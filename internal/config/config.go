@@ -3,6 +3,7 @@ package config
 
 import (
 	"os"
+	"strconv"
 	"time"
 )
 
@@ -13,6 +14,40 @@ type Config struct {
 	LogLevel      string
 	HTTPTimeout   time.Duration
 	HTTPPort      string
+
+	// In-memory cache of scraped results
+	CacheMaxEntries int
+	CacheListTTL    time.Duration
+	CacheSearchTTL  time.Duration
+	CacheArticleTTL time.Duration
+
+	// Crawl politeness towards the websites; an empty user agent uses
+	// client.DefaultUserAgent
+	CrawlUserAgent   string
+	CrawlDelay       time.Duration
+	CrawlParallelism int
+	RespectRobotsTxt bool
+
+	// Size limits of responses, in bytes after decompression
+	MaxPageSize     int64
+	MaxDocumentSize int64
+
+	// Retries of transient upstream failures; 1 attempt disables retries
+	RetryMaxAttempts int
+	RetryBaseDelay   time.Duration
+	RetryMaxDelay    time.Duration
+
+	// Per-site circuit breaker; a threshold of 0 disables it
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+
+	// On-disk cache of fetched pages, disabled when PageCacheDir is empty.
+	// It has no size bound: entries are replaced but never evicted.
+	PageCacheDir string
+	PageCacheTTL time.Duration
+
+	// Offline snapshot archive served instead of the live websites
+	SnapshotFile string
 }
 
 // Load returns a new Config loaded from environment variables.
@@ -23,6 +58,31 @@ func Load() *Config {
 		LogLevel:      getEnv("LOG_LEVEL", "info"),
 		HTTPTimeout:   getEnvDuration("HTTP_TIMEOUT", 30*time.Second),
 		HTTPPort:      getEnv("HTTP_PORT", ""),
+
+		CacheMaxEntries: getEnvInt("CACHE_MAX_ENTRIES", 500),
+		CacheListTTL:    getEnvDuration("CACHE_LIST_TTL", 24*time.Hour),
+		CacheSearchTTL:  getEnvDuration("CACHE_SEARCH_TTL", 15*time.Minute),
+		CacheArticleTTL: getEnvDuration("CACHE_ARTICLE_TTL", time.Hour),
+
+		CrawlUserAgent:   getEnv("CRAWL_USER_AGENT", ""),
+		CrawlDelay:       getEnvDuration("CRAWL_DELAY", time.Second),
+		CrawlParallelism: getEnvInt("CRAWL_PARALLELISM", 1),
+		RespectRobotsTxt: getEnvBool("RESPECT_ROBOTS_TXT", true),
+
+		MaxPageSize:     int64(getEnvInt("MAX_PAGE_SIZE", 5<<20)),
+		MaxDocumentSize: int64(getEnvInt("MAX_DOCUMENT_SIZE", 20<<20)),
+
+		RetryMaxAttempts: getEnvInt("RETRY_MAX_ATTEMPTS", 3),
+		RetryBaseDelay:   getEnvDuration("RETRY_BASE_DELAY", 500*time.Millisecond),
+		RetryMaxDelay:    getEnvDuration("RETRY_MAX_DELAY", 10*time.Second),
+
+		BreakerThreshold: getEnvInt("BREAKER_THRESHOLD", 5),
+		BreakerCooldown:  getEnvDuration("BREAKER_COOLDOWN", 30*time.Second),
+
+		PageCacheDir: getEnv("PAGE_CACHE_DIR", ""),
+		PageCacheTTL: getEnvDuration("PAGE_CACHE_TTL", 6*time.Hour),
+
+		SnapshotFile: getEnv("SNAPSHOT_FILE", ""),
 	}
 }
 
@@ -41,3 +101,21 @@ func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
 	}
 	return defaultValue
 }
+
+func getEnvInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if i, err := strconv.Atoi(value); err == nil {
+			return i
+		}
+	}
+	return defaultValue
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,92 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/guigui42/mcp-vosdroits/internal/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// GetDocumentInput defines the input schema for get_document.
+type GetDocumentInput struct {
+	URL string `json:"url" jsonschema:"URL of a PDF document on service-public.gouv.fr or impots.gouv.fr, such as a Cerfa form (pdf_url of get_form) or the notice of a tax form"`
+}
+
+// GetDocumentOutput defines the output schema for get_document.
+type GetDocumentOutput struct {
+	URL     string       `json:"url" jsonschema:"URL of the document"`
+	Title   string       `json:"title,omitempty" jsonschema:"Title of the document, from its metadata"`
+	Author  string       `json:"author,omitempty" jsonschema:"Author of the document, usually the issuing administration"`
+	Subject string       `json:"subject,omitempty" jsonschema:"Subject of the document, from its metadata"`
+	Created string       `json:"created,omitempty" jsonschema:"Creation date of the document (YYYY-MM-DD), when known"`
+	Pages   []PageOutput `json:"pages,omitempty" jsonschema:"Text of each page of the document, in order"`
+}
+
+// PageOutput represents the text of one page of a document.
+type PageOutput struct {
+	Number int    `json:"number" jsonschema:"Page number, starting at 1"`
+	Text   string `json:"text" jsonschema:"Text of the page, one line per line of the document"`
+}
+
+func documentOutput(doc *client.PDFDocument) GetDocumentOutput {
+	output := GetDocumentOutput{
+		URL:     doc.URL,
+		Title:   doc.Title,
+		Author:  doc.Author,
+		Subject: doc.Subject,
+		Pages:   make([]PageOutput, len(doc.Pages)),
+	}
+	if !doc.Created.IsZero() {
+		output.Created = doc.Created.Format("2006-01-02")
+	}
+	for i, p := range doc.Pages {
+		output.Pages[i] = PageOutput{Number: p.Number, Text: p.Text}
+	}
+	return output
+}
+
+// sourceFor returns the first of sources that owns rawURL. Relative and
+// unknown URLs go to the first source, which resolves or rejects them.
+func sourceFor(rawURL string, sources ...client.Source) client.Source {
+	for _, src := range sources {
+		if src.Owns(rawURL) {
+			return src
+		}
+	}
+	return sources[0]
+}
+
+func registerGetDocument(server *mcp.Server, httpClient *client.Client, impotsClient *client.ImpotsClient) error {
+	tool := &mcp.Tool{
+		Name:        "get_document",
+		Description: "Extract the text and metadata of a PDF document from service-public.gouv.fr or impots.gouv.fr, such as a Cerfa form (the pdf_url returned by get_form or list_forms) or the notice of a tax form. Returns the text page by page. For web pages, use get_article or get_impots_article instead.",
+	}
+
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetDocumentInput) (*mcp.CallToolResult, GetDocumentOutput, error) {
+		if input.URL == "" {
+			return nil, GetDocumentOutput{}, invalidInput("url cannot be empty")
+		}
+
+		src := sourceFor(input.URL, httpClient, impotsClient)
+		doc, err := src.GetPDF(ctx, input.URL)
+		if err != nil {
+			return nil, GetDocumentOutput{}, withHint(fmt.Errorf("failed to get document from %s: %w", input.URL, err), "Use get_article or get_impots_article for web pages. If the PDF cannot be read, give the user its URL to open it directly.")
+		}
+
+		title := doc.Title
+		if title == "" {
+			title = "untitled document"
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: snapshotNote(src, fmt.Sprintf("Retrieved PDF document: %s (%d pages)\n\nSource: %s\n\nIMPORTANT: Always provide this source URL to the user so they can download the original document.", title, len(doc.Pages), doc.URL)),
+				},
+			},
+		}, documentOutput(doc), nil
+	}
+
+	return addTool(server, tool, handler)
+}
@@ -0,0 +1,135 @@
+package tools
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/guigui42/mcp-vosdroits/internal/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestSourceFor(t *testing.T) {
+	httpClient := client.New(time.Second)
+	impotsClient := client.NewImpotsClient(time.Second)
+
+	tests := []struct {
+		url  string
+		want client.Source
+	}{
+		{"https://www.impots.gouv.fr/sites/default/files/formulaires/2042/2025/notice-2042.pdf", impotsClient},
+		{"https://IMPOTS.gouv.fr./notice.pdf", impotsClient},
+		{"https://www.service-public.gouv.fr/files/cerfa_12100-03.pdf", httpClient},
+		{"https://www.formulaires.service-public.gouv.fr/gf/cerfa_12100.do", httpClient},
+		{"/files/cerfa_12100-03.pdf", httpClient},
+		{"https://impots.gouv.fr.example.com/notice.pdf", httpClient},
+	}
+
+	for _, tt := range tests {
+		if got := sourceFor(tt.url, httpClient, impotsClient); got != tt.want {
+			t.Errorf("sourceFor(%q) = %s, want %s", tt.url, got.Name(), tt.want.Name())
+		}
+	}
+}
+
+func TestDocumentOutput(t *testing.T) {
+	doc := &client.PDFDocument{
+		URL:     "https://www.service-public.gouv.fr/files/cerfa_12100-03.pdf",
+		Title:   "Cerfa 12100*03",
+		Created: time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC),
+		Pages:   []client.PDFPage{{Number: 1, Text: "Demande de carte nationale d'identité"}},
+	}
+
+	output := documentOutput(doc)
+	if output.Created != "2023-01-15" || len(output.Pages) != 1 || output.Pages[0].Number != 1 {
+		t.Errorf("documentOutput() = %+v", output)
+	}
+
+	// Unknown dates are left out
+	doc.Created = time.Time{}
+	if output := documentOutput(doc); output.Created != "" {
+		t.Errorf("Created = %q, want empty", output.Created)
+	}
+}
+
+func TestGetDocumentOverSession(t *testing.T) {
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/files/cerfa_12100-03.pdf":
+			http.ServeFile(w, r, "../client/testdata/service-public/cerfa_12100-03.pdf")
+		case "/particuliers/vosdroits/F1342":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			http.ServeFile(w, r, "../client/testdata/service-public/F1342.html")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer site.Close()
+
+	noRobots := client.WithCrawl(client.CrawlConfig{Parallelism: 1, IgnoreRobots: true})
+	httpClient := client.New(5*time.Second, client.WithBaseURL(site.URL), noRobots)
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v0.0.0"}, nil)
+	if err := registerListForms(server, httpClient); err != nil {
+		t.Fatalf("registerListForms() error = %v", err)
+	}
+	err := registerGetDocument(server, httpClient,
+		client.NewImpotsClient(5*time.Second, client.WithBaseURL(site.URL), noRobots))
+	if err != nil {
+		t.Fatalf("registerGetDocument() error = %v", err)
+	}
+
+	ctx := context.Background()
+	session := connect(t, server)
+
+	// The PDF of a Cerfa form is read from the link list_forms returns
+	res, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "list_forms",
+		Arguments: map[string]any{"url": "/particuliers/vosdroits/F1342"},
+	})
+	if err != nil || res.IsError {
+		t.Fatalf("CallTool(list_forms) = %v, %v", res, err)
+	}
+	forms, _ := res.StructuredContent.(map[string]any)["forms"].([]any)
+	if len(forms) == 0 {
+		t.Fatalf("list_forms returned no forms: %v", res.StructuredContent)
+	}
+	pdfURL, _ := forms[0].(map[string]any)["pdf_url"].(string)
+	if pdfURL != site.URL+"/files/cerfa_12100-03.pdf" {
+		t.Fatalf("pdf_url = %q", pdfURL)
+	}
+
+	res, err = session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "get_document",
+		Arguments: map[string]any{"url": pdfURL},
+	})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if res.IsError {
+		t.Fatalf("CallTool() failed: %v", res.Content[0].(*mcp.TextContent).Text)
+	}
+	output, _ := res.StructuredContent.(map[string]any)
+	pages, _ := output["pages"].([]any)
+	if output["created"] != "2023-01-15" || len(pages) != 1 {
+		t.Errorf("StructuredContent = %v", res.StructuredContent)
+	}
+	if text := res.Content[0].(*mcp.TextContent).Text; !strings.Contains(text, "Cerfa 12100*03") {
+		t.Errorf("text = %q", text)
+	}
+
+	// Web pages are refused with the advice to use get_article
+	res, err = session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "get_document",
+		Arguments: map[string]any{"url": "/particuliers/vosdroits/F1342"},
+	})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	payload, _ := res.Meta["error"].(map[string]any)
+	if !res.IsError || payload["code"] != codeInvalidInput {
+		t.Errorf("error payload = %v, want %s", payload, codeInvalidInput)
+	}
+}
@@ -0,0 +1,159 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/guigui42/mcp-vosdroits/internal/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Error codes of ToolError, one per kind of client error.
+const (
+	codeInvalidInput        = "invalid_input"
+	codeNotFound            = "not_found"
+	codeForbiddenDomain     = "forbidden_domain"
+	codeDisallowed          = "disallowed"
+	codeUpstreamUnavailable = "upstream_unavailable"
+	codeRateLimited         = "rate_limited"
+	codeParseFailure        = "parse_failure"
+	codeBinaryDocument      = "binary_document"
+	codeTooLarge            = "too_large"
+	codeTimeout             = "timeout"
+	codeCancelled           = "cancelled"
+	codeInternal            = "internal"
+)
+
+// errorKinds maps the client error kinds to codes and to the advice given to
+// the model, in the order they are tested.
+var errorKinds = []struct {
+	kind error
+	code string
+	hint string
+}{
+	{client.ErrInvalidInput, codeInvalidInput, "Fix the arguments and call the tool again."},
+	{client.ErrForbiddenDomain, codeForbiddenDomain, "Only URLs of the tool's website can be fetched. Check that you are using the right tool for this URL."},
+	{client.ErrDisallowed, codeDisallowed, "Do NOT retry this URL: the website does not allow automated access to it. Give the user the URL to open in their browser."},
+	{client.ErrNotFound, codeNotFound, "Do NOT retry this same URL. Tell the user that this page could not be found and search for alternative procedures instead."},
+	{client.ErrRateLimited, codeRateLimited, "The website asked to slow down. Wait a little before trying again."},
+	{client.ErrTimeout, codeTimeout, "The website did not answer in time. You may try again later."},
+	{context.DeadlineExceeded, codeTimeout, "The website did not answer in time. You may try again later."},
+	{client.ErrUpstreamUnavailable, codeUpstreamUnavailable, "The website is temporarily unavailable. You may try again later, or suggest the user visits it directly."},
+	{client.ErrBinaryDocument, codeBinaryDocument, "Do NOT retry this URL with this tool: it is a file, not a web page. If it is a PDF document, use get_document to read it; otherwise give the user the URL to download it."},
+	{client.ErrTooLarge, codeTooLarge, "Do NOT retry this URL: the page is too large to be processed. Give the user the URL to open in their browser."},
+	{client.ErrParseFailure, codeParseFailure, "Do NOT retry this same URL: the page layout is not supported. Suggest the user visits the URL directly in their browser."},
+	{context.Canceled, codeCancelled, ""},
+}
+
+// ToolError is the structured payload of a failed tool call, sent in the
+// "error" entry of the result metadata.
+type ToolError struct {
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+	Retryable  bool   `json:"retryable"`
+	StatusCode int    `json:"status_code,omitempty"`
+	// RetryAfterSeconds is how long the website asked to wait, if it did.
+	RetryAfterSeconds int    `json:"retry_after_seconds,omitempty"`
+	Hint              string `json:"hint,omitempty"`
+}
+
+// hintError attaches tool-specific advice to an error.
+type hintError struct {
+	err  error
+	hint string
+}
+
+func (e *hintError) Error() string { return e.err.Error() }
+func (e *hintError) Unwrap() error { return e.err }
+
+// withHint returns err with hint replacing the default advice of its kind,
+// unless err is temporary.
+func withHint(err error, hint string) error {
+	return &hintError{err: err, hint: hint}
+}
+
+// invalidInput returns an input validation error formatted as with fmt.Errorf.
+func invalidInput(format string, args ...any) error {
+	return &client.Error{Kind: client.ErrInvalidInput, Err: fmt.Errorf(format, args...)}
+}
+
+// newToolError classifies err.
+func newToolError(err error) ToolError {
+	te := ToolError{
+		Code:      codeInternal,
+		Message:   err.Error(),
+		Retryable: client.Retryable(err),
+	}
+	for _, k := range errorKinds {
+		if errors.Is(err, k.kind) {
+			te.Code, te.Hint = k.code, k.hint
+			break
+		}
+	}
+
+	var clientErr *client.Error
+	if errors.As(err, &clientErr) {
+		te.StatusCode = clientErr.StatusCode
+		te.RetryAfterSeconds = int(clientErr.RetryAfter.Round(time.Second) / time.Second)
+	}
+	// Advice on using the tools does not help with temporary failures
+	var he *hintError
+	if errors.As(err, &he) && !te.Retryable {
+		te.Hint = he.hint
+	}
+	return te
+}
+
+// errorResult builds the result reporting err to the model: a readable
+// message, and the ToolError in the result metadata for programmatic use.
+func errorResult(err error) *mcp.CallToolResult {
+	te := newToolError(err)
+
+	text := fmt.Sprintf("ERROR (%s): %s", te.Code, te.Message)
+	switch {
+	case te.RetryAfterSeconds > 0:
+		text += fmt.Sprintf("\n\nThis error is temporary: the website asked to wait %d seconds.", te.RetryAfterSeconds)
+	case te.Retryable:
+		text += "\n\nThis error is temporary."
+	}
+	if te.Hint != "" {
+		text += "\n\n" + te.Hint
+	}
+
+	return &mcp.CallToolResult{
+		Meta:    mcp.Meta{"error": te},
+		Content: []mcp.Content{&mcp.TextContent{Text: text}},
+		IsError: true,
+	}
+}
+
+// handleErrors turns the errors returned by h into structured error results,
+// so that every tool reports failures the same way. Error results carry no
+// output: the SDK validates the output of Out against the output schema,
+// which a zero Out, with its nil slices, would fail.
+func handleErrors[In, Out any](h mcp.ToolHandlerFor[In, Out]) mcp.ToolHandlerFor[In, any] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input In) (*mcp.CallToolResult, any, error) {
+		res, out, err := h(ctx, req, input)
+		if err != nil {
+			return errorResult(err), nil, nil
+		}
+		return res, out, nil
+	}
+}
+
+// addTool adds tool to server with the handler h wrapped by handleErrors.
+// The output schema is inferred from Out, as mcp.AddTool would.
+func addTool[In, Out any](server *mcp.Server, tool *mcp.Tool, h mcp.ToolHandlerFor[In, Out]) error {
+	if tool.OutputSchema == nil {
+		schema, err := jsonschema.For[Out](&jsonschema.ForOptions{})
+		if err != nil {
+			return fmt.Errorf("output schema of %s: %w", tool.Name, err)
+		}
+		tool.OutputSchema = schema
+	}
+	mcp.AddTool(server, tool, handleErrors(h))
+	return nil
+}
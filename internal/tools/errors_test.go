@@ -0,0 +1,182 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/guigui42/mcp-vosdroits/internal/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestNewToolError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		code      string
+		retryable bool
+	}{
+		{"invalid input", invalidInput("url cannot be empty"), codeInvalidInput, false},
+		{"not found", fmt.Errorf("get: %w", &client.Error{Kind: client.ErrNotFound, StatusCode: 404, Err: errors.New("404")}), codeNotFound, false},
+		{"forbidden domain", &client.Error{Kind: client.ErrForbiddenDomain, Err: errors.New("wrong host")}, codeForbiddenDomain, false},
+		{"upstream", &client.Error{Kind: client.ErrUpstreamUnavailable, Err: errors.New("503")}, codeUpstreamUnavailable, true},
+		{"rate limited", &client.Error{Kind: client.ErrRateLimited, Err: errors.New("429")}, codeRateLimited, true},
+		{"parse failure", &client.Error{Kind: client.ErrParseFailure, Err: errors.New("no content")}, codeParseFailure, false},
+		{"timeout", &client.Error{Kind: client.ErrTimeout, Err: errors.New("slow")}, codeTimeout, true},
+		{"deadline", context.DeadlineExceeded, codeTimeout, true},
+		{"unclassified", errors.New("boom"), codeInternal, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			te := newToolError(tt.err)
+			if te.Code != tt.code || te.Retryable != tt.retryable {
+				t.Errorf("newToolError() = %+v, want code %q, retryable %v", te, tt.code, tt.retryable)
+			}
+			if te.Message != tt.err.Error() {
+				t.Errorf("Message = %q, want %q", te.Message, tt.err.Error())
+			}
+		})
+	}
+
+	if te := newToolError(tests[1].err); te.StatusCode != 404 {
+		t.Errorf("StatusCode = %d, want 404", te.StatusCode)
+	}
+
+	limited := &client.Error{Kind: client.ErrRateLimited, StatusCode: 429, RetryAfter: 90 * time.Second, Err: errors.New("429")}
+	if te := newToolError(limited); te.RetryAfterSeconds != 90 {
+		t.Errorf("RetryAfterSeconds = %d, want 90", te.RetryAfterSeconds)
+	}
+}
+
+func TestNewToolErrorHint(t *testing.T) {
+	notFound := &client.Error{Kind: client.ErrNotFound, Err: errors.New("missing")}
+	if te := newToolError(withHint(notFound, "Use list_categories.")); te.Hint != "Use list_categories." {
+		t.Errorf("Hint = %q, want the tool hint", te.Hint)
+	}
+
+	// Temporary failures keep the advice to try again
+	unavailable := &client.Error{Kind: client.ErrUpstreamUnavailable, Err: errors.New("503")}
+	if te := newToolError(withHint(unavailable, "Use list_categories.")); !strings.Contains(te.Hint, "try again") {
+		t.Errorf("Hint = %q, want retry advice", te.Hint)
+	}
+}
+
+func TestToolErrorOverSession(t *testing.T) {
+	site := httptest.NewServer(http.NotFoundHandler())
+	defer site.Close()
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v0.0.0"}, nil)
+	if err := registerGetArticle(server, client.New(5*time.Second, client.WithBaseURL(site.URL))); err != nil {
+		t.Fatalf("registerGetArticle() error = %v", err)
+	}
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	if _, err := server.Connect(ctx, serverTransport, nil); err != nil {
+		t.Fatalf("server.Connect() error = %v", err)
+	}
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "v0.0.0"}, nil).Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client.Connect() error = %v", err)
+	}
+	defer session.Close()
+
+	res, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name:      "get_article",
+		Arguments: map[string]any{"url": "/particuliers/vosdroits/F0000"},
+	})
+	if err != nil {
+		t.Fatalf("CallTool() error = %v", err)
+	}
+	if !res.IsError {
+		t.Fatal("CallTool() IsError = false, want true")
+	}
+
+	payload, ok := res.Meta["error"].(map[string]any)
+	if !ok {
+		t.Fatalf("Meta = %v, want an error payload", res.Meta)
+	}
+	if payload["code"] != codeNotFound || payload["retryable"] != false {
+		t.Errorf("error payload = %v, want non-retryable %s", payload, codeNotFound)
+	}
+	if text := res.Content[0].(*mcp.TextContent).Text; !strings.HasPrefix(text, "ERROR (not_found)") {
+		t.Errorf("text = %q", text)
+	}
+}
+
+func TestToolErrorsOverSessionEveryTool(t *testing.T) {
+	site := httptest.NewServer(http.NotFoundHandler())
+	defer site.Close()
+
+	opts := []client.Option{
+		client.WithBaseURL(site.URL),
+		client.WithCrawl(client.CrawlConfig{Parallelism: 1, IgnoreRobots: true}),
+	}
+	httpClient := client.New(5*time.Second, opts...)
+	impotsClient := client.NewImpotsClient(5*time.Second, opts...)
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v0.0.0"}, nil)
+	for name, register := range map[string]func() error{
+		"search_procedures":      func() error { return registerSearchProcedures(server, httpClient) },
+		"get_article":            func() error { return registerGetArticle(server, httpClient) },
+		"list_categories":        func() error { return registerListCategories(server, httpClient) },
+		"browse_category":        func() error { return registerBrowseCategory(server, httpClient) },
+		"list_life_events":       func() error { return registerListLifeEvents(server, httpClient) },
+		"get_life_event_details": func() error { return registerGetLifeEventDetails(server, httpClient) },
+		"list_forms":             func() error { return registerListForms(server, httpClient) },
+		"get_form":               func() error { return registerGetForm(server, httpClient) },
+		"get_document":           func() error { return registerGetDocument(server, httpClient, impotsClient) },
+		"impots tools":           func() error { return RegisterImpotsTools(server, impotsClient) },
+	} {
+		if err := register(); err != nil {
+			t.Fatalf("registering %s: %v", name, err)
+		}
+	}
+	session := connect(t, server)
+
+	// Every failure is an error result, even when the output type has slices
+	// that the output schema requires to be arrays. list_impots_categories
+	// falls back to default categories instead of failing.
+	tests := []struct {
+		tool string
+		args map[string]any
+		code string
+	}{
+		{"search_procedures", map[string]any{"query": ""}, codeInvalidInput},
+		{"get_article", map[string]any{"url": "/particuliers/vosdroits/F0000"}, codeNotFound},
+		{"list_categories", map[string]any{"audience": "entreprises"}, codeInvalidInput},
+		{"browse_category", map[string]any{"url": ""}, codeInvalidInput},
+		{"list_life_events", map[string]any{"audience": "professionnels"}, codeInvalidInput},
+		{"get_life_event_details", map[string]any{"url": ""}, codeInvalidInput},
+		{"list_forms", map[string]any{"url": "https://example.com/fiche"}, codeForbiddenDomain},
+		{"get_form", map[string]any{"number": "not a number"}, codeInvalidInput},
+		{"get_document", map[string]any{"url": ""}, codeInvalidInput},
+		{"search_impots", map[string]any{"query": ""}, codeInvalidInput},
+		{"get_impots_article", map[string]any{"url": ""}, codeInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			res, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: tt.tool, Arguments: tt.args})
+			if err != nil {
+				t.Fatalf("CallTool() error = %v, want an error result", err)
+			}
+			if !res.IsError {
+				t.Fatal("CallTool() IsError = false, want true")
+			}
+			if res.StructuredContent != nil {
+				t.Errorf("StructuredContent = %v, want none on errors", res.StructuredContent)
+			}
+			payload, _ := res.Meta["error"].(map[string]any)
+			if payload["code"] != tt.code {
+				t.Errorf("error payload = %v, want code %s", payload, tt.code)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/guigui42/mcp-vosdroits/internal/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ListFormsInput defines the input schema for list_forms.
+type ListFormsInput struct {
+	URL string `json:"url,omitempty" jsonschema:"URL of a service-public.gouv.fr fiche whose Cerfa forms to list. When omitted, lists only the forms found in fiches this server happened to retrieve earlier, which is not a catalogue of all forms."`
+}
+
+// ListFormsOutput defines the output schema for list_forms.
+type ListFormsOutput struct {
+	Forms []FormOutput `json:"forms" jsonschema:"Cerfa forms. Use get_form with a Cerfa number for details."`
+}
+
+// FormOutput represents a Cerfa form.
+type FormOutput struct {
+	Number string   `json:"number" jsonschema:"Cerfa number, with its version when known (e.g., 12100*03)"`
+	Title  string   `json:"title,omitempty" jsonschema:"Title of the form"`
+	URL    string   `json:"url,omitempty" jsonschema:"Page presenting the form or its online version"`
+	PDFURL string   `json:"pdf_url,omitempty" jsonschema:"URL of the downloadable PDF form. Use get_document to read its text."`
+	Fiches []string `json:"fiches" jsonschema:"URLs of the fiches referencing the form. Use get_article to learn when the form is needed."`
+}
+
+func formOutput(f client.Form) FormOutput {
+	return FormOutput{
+		Number: f.Number,
+		Title:  f.Title,
+		URL:    f.URL,
+		PDFURL: f.PDFURL,
+		Fiches: f.Fiches,
+	}
+}
+
+func registerListForms(server *mcp.Server, httpClient *client.Client) error {
+	tool := &mcp.Tool{
+		Name:        "list_forms",
+		Description: "List the Cerfa forms (official French administrative forms, e.g. 'formulaire 12100*03') referenced by a service-public.gouv.fr fiche, with their titles, PDF links and the fiches that reference them. Without a URL, lists only the forms found in fiches this server happened to retrieve earlier (by any tool call), not every existing form; pass the URL of a fiche to get its forms.",
+	}
+
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ListFormsInput) (*mcp.CallToolResult, ListFormsOutput, error) {
+		forms, err := httpClient.ListForms(ctx, input.URL)
+		if err != nil {
+			return nil, ListFormsOutput{}, fmt.Errorf("failed to list forms: %w", err)
+		}
+
+		output := ListFormsOutput{
+			Forms: make([]FormOutput, len(forms)),
+		}
+		for i, f := range forms {
+			output.Forms[i] = formOutput(f)
+		}
+
+		message := fmt.Sprintf("Found %d Cerfa forms", len(forms))
+		if input.URL != "" {
+			message += fmt.Sprintf(" referenced by %s", input.URL)
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: snapshotNote(httpClient, message),
+				},
+			},
+		}, output, nil
+	}
+
+	return addTool(server, tool, handler)
+}
+
+// GetFormInput defines the input schema for get_form.
+type GetFormInput struct {
+	Number string `json:"number" jsonschema:"Cerfa number of the form (e.g., 12100*03, 12100 or cerfa 12100)"`
+}
+
+func registerGetForm(server *mcp.Server, httpClient *client.Client) error {
+	tool := &mcp.Tool{
+		Name:        "get_form",
+		Description: "Look up a Cerfa form by number (e.g., '12100*03' or '12100') and return its title, PDF link and the service-public.gouv.fr fiches that reference it.",
+	}
+
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetFormInput) (*mcp.CallToolResult, FormOutput, error) {
+		if input.Number == "" {
+			return nil, FormOutput{}, invalidInput("number cannot be empty")
+		}
+
+		form, err := httpClient.GetForm(ctx, input.Number)
+		if err != nil {
+			return nil, FormOutput{}, withHint(fmt.Errorf("failed to get form %s: %w", input.Number, err), "Use search_procedures to find the fiche describing the procedure, then list_forms with its URL.")
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: snapshotNote(httpClient, fmt.Sprintf("Found Cerfa form %s, referenced by %d fiches.", form.Number, len(form.Fiches))),
+				},
+			},
+		}, formOutput(*form), nil
+	}
+
+	return addTool(server, tool, handler)
+}
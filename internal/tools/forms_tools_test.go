@@ -0,0 +1,42 @@
+package tools
+
+import (
+	"testing"
+	"time"
+
+	"github.com/guigui42/mcp-vosdroits/internal/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestRegisterFormTools(t *testing.T) {
+	server := mcp.NewServer(
+		&mcp.Implementation{
+			Name:    "test-server",
+			Version: "1.0.0",
+		},
+		nil,
+	)
+
+	httpClient := client.New(10 * time.Second)
+
+	if err := registerListForms(server, httpClient); err != nil {
+		t.Fatalf("registerListForms failed: %v", err)
+	}
+	if err := registerGetForm(server, httpClient); err != nil {
+		t.Fatalf("registerGetForm failed: %v", err)
+	}
+}
+
+func TestFormOutput(t *testing.T) {
+	form := client.Form{
+		Number: "12100*03",
+		Title:  "Formulaire 12100*03",
+		PDFURL: "https://www.service-public.gouv.fr/files/cerfa_12100-03.pdf",
+		Fiches: []string{"https://www.service-public.gouv.fr/particuliers/vosdroits/F1342"},
+	}
+
+	output := formOutput(form)
+	if output.Number != form.Number || output.PDFURL != form.PDFURL || len(output.Fiches) != 1 {
+		t.Errorf("formOutput() = %+v", output)
+	}
+}
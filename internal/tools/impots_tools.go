@@ -29,13 +29,19 @@ func RegisterImpotsTools(server *mcp.Server, impotsClient *client.ImpotsClient)
 
 // SearchImpotsInput defines the input schema for search_impots.
 type SearchImpotsInput struct {
-	Query string `json:"query" jsonschema:"Search query for tax information and forms"`
-	Limit int    `json:"limit,omitempty" jsonschema:"Maximum number of results to return (1-100)"`
+	Query  string `json:"query" jsonschema:"Search query for tax information and forms"`
+	Limit  int    `json:"limit,omitempty" jsonschema:"Maximum number of results to return (1-100)"`
+	Cursor string `json:"cursor,omitempty" jsonschema:"Cursor returned as next_cursor by a previous call, to get the following page of results. The query must be the same."`
 }
 
 // SearchImpotsOutput defines the output schema for search_impots.
 type SearchImpotsOutput struct {
-	Results []ImpotsResult `json:"results" jsonschema:"List of matching tax documents and articles"`
+	Results      []ImpotsResult `json:"results" jsonschema:"List of matching tax documents and articles"`
+	TotalResults int            `json:"total_results,omitempty" jsonschema:"Total number of matches reported by the website, when known"`
+	Page         int            `json:"page" jsonschema:"Number of this page of results, starting at 1"`
+	NextCursor   string         `json:"next_cursor,omitempty" jsonschema:"Pass as cursor to get the next page of results. Absent on the last page."`
+	EmptyReason  string         `json:"empty_reason,omitempty" jsonschema:"Why there are no results: no_match (nothing matches the query), site_error (the website could not be reached), parse_failure (the results page could not be read) or offline (the search is not in the offline snapshot being served)"`
+	Suggestions  []string       `json:"suggestions,omitempty" jsonschema:"Alternative queries suggested by the website, such as spelling corrections or related searches"`
 }
 
 // ImpotsResult represents a single search result from impots.gouv.fr.
@@ -59,16 +65,22 @@ func registerSearchImpots(server *mcp.Server, impotsClient *client.ImpotsClient)
 		}
 
 		if input.Query == "" {
-			return nil, SearchImpotsOutput{}, fmt.Errorf("query cannot be empty")
+			return nil, SearchImpotsOutput{}, invalidInput("query cannot be empty")
 		}
 
-		results, err := impotsClient.SearchImpots(ctx, input.Query, input.Limit)
+		page, err := impotsClient.SearchImpotsPage(ctx, input.Query, input.Limit, input.Cursor)
 		if err != nil {
 			return nil, SearchImpotsOutput{}, fmt.Errorf("search failed: %w", err)
 		}
+		results := page.Results
 
 		output := SearchImpotsOutput{
-			Results: make([]ImpotsResult, len(results)),
+			Results:      make([]ImpotsResult, len(results)),
+			TotalResults: page.Total,
+			Page:         page.Page,
+			NextCursor:   page.NextCursor,
+			EmptyReason:  string(page.EmptyReason),
+			Suggestions:  page.Suggestions,
 		}
 		for i, r := range results {
 			output.Results[i] = ImpotsResult{
@@ -80,31 +92,38 @@ func registerSearchImpots(server *mcp.Server, impotsClient *client.ImpotsClient)
 			}
 		}
 
+		message := fmt.Sprintf("Found %d tax documents.", len(results)) + paginationNote(page.Total, page.Page, page.NextCursor)
+		if len(results) == 0 {
+			message = emptySearchMessage(impotsClient.Name(), input.Query, page.EmptyReason, page.Suggestions, page.Err)
+		}
+
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{
-					Text: fmt.Sprintf("Found %d tax documents", len(results)),
+					Text: snapshotNote(impotsClient, message),
 				},
 			},
 		}, output, nil
 	}
 
-	mcp.AddTool(server, tool, handler)
-	return nil
+	return addTool(server, tool, handler)
 }
 
 // GetImpotsArticleInput defines the input schema for get_impots_article.
 type GetImpotsArticleInput struct {
-	URL string `json:"url" jsonschema:"URL of the tax article or form to retrieve from impots.gouv.fr"`
+	URL    string `json:"url" jsonschema:"URL of the tax article or form to retrieve from impots.gouv.fr"`
+	Format string `json:"format,omitempty" jsonschema:"Format of the returned content: text (default) for plain text, or markdown to keep headings, lists, bold text, links and tables"`
 }
 
 // GetImpotsArticleOutput defines the output schema for get_impots_article.
 type GetImpotsArticleOutput struct {
-	Title       string `json:"title" jsonschema:"Title of the tax document"`
-	Content     string `json:"content" jsonschema:"Full content of the document"`
-	URL         string `json:"url" jsonschema:"URL of the document"`
-	Type        string `json:"type,omitempty" jsonschema:"Type of document"`
-	Description string `json:"description,omitempty" jsonschema:"Brief description"`
+	Title       string        `json:"title" jsonschema:"Title of the tax document"`
+	Content     string        `json:"content" jsonschema:"Full content of the document"`
+	URL         string        `json:"url" jsonschema:"URL of the document"`
+	Type        string        `json:"type,omitempty" jsonschema:"Type of document"`
+	Description string        `json:"description,omitempty" jsonschema:"Brief description"`
+	Tables      []TableOutput `json:"tables,omitempty" jsonschema:"Tables of the document, such as tax brackets, thresholds or deadlines"`
+	Links       []LinkOutput  `json:"links,omitempty" jsonschema:"Outbound links of the document, such as form PDFs, online services and legal texts"`
 }
 
 func registerGetImpotsArticle(server *mcp.Server, impotsClient *client.ImpotsClient) error {
@@ -115,53 +134,42 @@ func registerGetImpotsArticle(server *mcp.Server, impotsClient *client.ImpotsCli
 
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetImpotsArticleInput) (*mcp.CallToolResult, GetImpotsArticleOutput, error) {
 		if input.URL == "" {
-			return nil, GetImpotsArticleOutput{}, fmt.Errorf("url cannot be empty")
+			return nil, GetImpotsArticleOutput{}, invalidInput("url cannot be empty")
+		}
+		if err := validateFormat(input.Format); err != nil {
+			return nil, GetImpotsArticleOutput{}, err
 		}
 
 		// Check if URL is from service-public.fr domain and provide helpful error
 		if strings.Contains(input.URL, "service-public.fr") {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					&mcp.TextContent{
-						Text: fmt.Sprintf("ERROR: WRONG TOOL! The URL %s is from service-public.fr domain.\n\nget_impots_article tool ONLY works with impots.gouv.fr URLs (French tax services).\n\nCORRECT TOOL TO USE: get_article (for service-public.fr URLs)\n\nPlease use the get_article tool instead to retrieve this document.", input.URL),
-					},
-				},
-				IsError: true,
-			}, GetImpotsArticleOutput{}, fmt.Errorf("wrong domain: URL is from service-public.fr but this tool only works with impots.gouv.fr - use get_article tool instead")
+			return nil, GetImpotsArticleOutput{}, withHint(&client.Error{Kind: client.ErrForbiddenDomain, Err: fmt.Errorf("wrong domain: URL %s is from service-public.fr but this tool only works with impots.gouv.fr", input.URL)}, "CORRECT TOOL TO USE: get_article (for service-public.fr URLs). Please use the get_article tool instead to retrieve this document.")
 		}
 
 		article, err := impotsClient.GetImpotsArticle(ctx, input.URL)
 		if err != nil {
-			// Return a clear error message that discourages retrying the same URL
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					&mcp.TextContent{
-						Text: fmt.Sprintf("ERROR: Unable to retrieve tax document from %s. Reason: %v\n\nDo NOT retry this same URL. Instead, inform the user that this specific document could not be retrieved and suggest they visit the URL directly in their browser, or try searching for alternative tax documents.", input.URL, err),
-					},
-				},
-				IsError: true,
-			}, GetImpotsArticleOutput{}, fmt.Errorf("failed to get article from %s: %w", input.URL, err)
+			return nil, GetImpotsArticleOutput{}, fmt.Errorf("failed to get article from %s: %w", input.URL, err)
 		}
 
 		output := GetImpotsArticleOutput{
 			Title:       article.Title,
-			Content:     article.Content,
+			Content:     selectContent(input.Format, article.Content, article.Markdown),
 			URL:         article.URL,
 			Type:        article.Type,
 			Description: article.Description,
+			Tables:      tablesOutput(article.Tables),
+			Links:       linksOutput(article.Links),
 		}
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{
-					Text: fmt.Sprintf("Retrieved tax document: %s\n\nSource: %s\n\nIMPORTANT: Always provide this source URL to the user so they can access the original document.", article.Title, article.URL),
+					Text: snapshotNote(impotsClient, fmt.Sprintf("Retrieved tax document: %s\n\nSource: %s\n\nIMPORTANT: Always provide this source URL to the user so they can access the original document.", article.Title, article.URL)),
 				},
 			},
 		}, output, nil
 	}
 
-	mcp.AddTool(server, tool, handler)
-	return nil
+	return addTool(server, tool, handler)
 }
 
 // ListImpotsCategoriesOutput defines the output schema for list_impots_categories.
@@ -204,12 +212,11 @@ func registerListImpotsCategories(server *mcp.Server, impotsClient *client.Impot
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{
-					Text: fmt.Sprintf("Found %d tax categories", len(categories)),
+					Text: snapshotNote(impotsClient, fmt.Sprintf("Found %d tax categories", len(categories))),
 				},
 			},
 		}, output, nil
 	}
 
-	mcp.AddTool(server, tool, handler)
-	return nil
+	return addTool(server, tool, handler)
 }
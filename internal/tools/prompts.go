@@ -0,0 +1,143 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// workflow is a guided administrative workflow, exposed as a prompt that
+// tells the model which tools to call in which order.
+type workflow struct {
+	name        string
+	title       string
+	description string
+	// goal completes "Help me ...".
+	goal string
+	// situationHint describes the situation argument, with examples.
+	situationHint string
+	steps         []workflowStep
+}
+
+// workflowStep is one step of a workflow. Steps without a tool are done by
+// the model from what it has gathered.
+type workflowStep struct {
+	tool string
+	text string
+}
+
+// workflows lists the prompts registered by RegisterPrompts.
+var workflows = []workflow{
+	{
+		name:          "renew_id_card",
+		title:         "Prepare my ID card renewal",
+		description:   "Guide the renewal of a French national identity card (carte nationale d'identité): conditions, documents to gather, forms and where to apply.",
+		goal:          "prepare the renewal of my French national identity card (carte nationale d'identité)",
+		situationHint: "Why the card is renewed, e.g. 'expired', 'lost', 'stolen', 'change of address', 'minor child'",
+		steps: []workflowStep{
+			{"search_procedures", "search for 'renouvellement carte d'identité', adding the situation (e.g. 'perte', 'vol', 'mineur') when it is given."},
+			{"get_article", "retrieve the fiche that matches the situation best, to learn the conditions, the documents to gather and the cost (timbre fiscal)."},
+			{"list_forms", "list the Cerfa forms of that fiche, with the fiche URL."},
+			{"get_document", "read the Cerfa form PDF (pdf_url) when the user needs help filling it in."},
+			{"", "Write a checklist: online pre-application (pré-demande on ANTS), documents, fees, and how to book an appointment at a town hall (mairie) equipped for biometric cards in the user's département."},
+		},
+	},
+	{
+		name:          "after_birth",
+		title:         "What to do after a birth",
+		description:   "Guide the procedures following the birth of a child: declaration of birth, recognition, family benefits, health insurance and taxes.",
+		goal:          "know what to do after the birth of my child",
+		situationHint: "Family situation, e.g. 'parents not married', 'birth abroad', 'twins', 'single parent'",
+		steps: []workflowStep{
+			{"list_life_events", "find the life event about the arrival of a child."},
+			{"get_life_event_details", "retrieve that life event with the exact URL returned by list_life_events."},
+			{"get_article", "retrieve the fiches on the declaration of birth and, when relevant to the situation, on the recognition of the child (reconnaissance) and on family benefits (CAF)."},
+			{"search_impots", "search for 'naissance enfant à charge' to explain the effect on income tax (parts de quotient familial) and how to report it."},
+			{"", "Write a timeline of the steps with their deadlines, starting with the declaration of birth within 5 days, and the offices of the user's département involved (mairie, CAF, CPAM)."},
+		},
+	},
+	{
+		name:          "first_tax_return",
+		title:         "File my first income tax return",
+		description:   "Guide a first French income tax return: who must declare, how to create a tax account, which forms and boxes to fill in.",
+		goal:          "file my first French income tax return",
+		situationHint: "Situation of the taxpayer, e.g. 'student', 'first job', 'leaving the parental tax household', 'arrived from abroad'",
+		steps: []workflowStep{
+			{"search_impots", "search for 'première déclaration de revenus', adding the situation when it is given."},
+			{"get_impots_article", "retrieve the article explaining the first declaration: who must declare, the deadlines and how to get a tax number (numéro fiscal)."},
+			{"search_impots", "search for 'formulaire 2042' to find the form and its notice."},
+			{"get_document", "read the notice of form 2042 to point out the boxes that apply to the situation."},
+			{"search_procedures", "search for 'déclaration de revenus' on service-public.gouv.fr when the situation calls for more context, such as being attached to the parents' tax household (rattachement)."},
+			{"", "Write the steps in order, the deadline, which depends on the user's département, and the tax office (service des impôts des particuliers) to contact there."},
+		},
+	},
+}
+
+// workflowArguments are the arguments common to every workflow prompt.
+func workflowArguments(w workflow) []*mcp.PromptArgument {
+	return []*mcp.PromptArgument{
+		{
+			Name:        "departement",
+			Title:       "Département",
+			Description: "Département where the user lives, as a number or a name (e.g. '75', '2A', 'Gironde')",
+		},
+		{
+			Name:        "situation",
+			Title:       "Situation",
+			Description: w.situationHint,
+		},
+	}
+}
+
+// RegisterPrompts registers a prompt for each guided workflow with the
+// server. The prompts only refer to tools, so they are registered next to
+// RegisterTools.
+func RegisterPrompts(server *mcp.Server) {
+	for _, w := range workflows {
+		server.AddPrompt(&mcp.Prompt{
+			Name:        w.name,
+			Title:       w.title,
+			Description: w.description,
+			Arguments:   workflowArguments(w),
+		}, workflowHandler(w))
+	}
+}
+
+// workflowHandler returns the handler rendering the prompt of w.
+func workflowHandler(w workflow) mcp.PromptHandler {
+	return func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		return &mcp.GetPromptResult{
+			Description: w.description,
+			Messages: []*mcp.PromptMessage{{
+				Role:    "user",
+				Content: &mcp.TextContent{Text: w.render(req.Params.Arguments)},
+			}},
+		}, nil
+	}
+}
+
+// render returns the instructions of w for the given arguments.
+func (w workflow) render(args map[string]string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Help me %s.\n", w.goal)
+	if s := strings.TrimSpace(args["situation"]); s != "" {
+		fmt.Fprintf(&b, "My situation: %s.\n", strings.TrimSuffix(s, "."))
+	}
+	if d := strings.TrimSpace(args["departement"]); d != "" {
+		fmt.Fprintf(&b, "I live in the département %s.\n", d)
+	}
+
+	b.WriteString("\nWork through these steps with the tools of this server:\n")
+	for i, step := range w.steps {
+		if step.tool != "" {
+			fmt.Fprintf(&b, "%d. Call %s to %s\n", i+1, step.tool, step.text)
+		} else {
+			fmt.Fprintf(&b, "%d. %s\n", i+1, step.text)
+		}
+	}
+
+	b.WriteString("\nOnly state what the retrieved pages say, and give the source URL of each piece of information. If a step fails, say so and go on with the next one. When the département is unknown, ask for it only if the answer depends on it.")
+	return b.String()
+}
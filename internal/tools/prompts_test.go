@@ -0,0 +1,77 @@
+package tools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/guigui42/mcp-vosdroits/internal/config"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func TestWorkflowsUseRegisteredTools(t *testing.T) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v0.0.0"}, nil)
+	if err := RegisterTools(server, config.Load()); err != nil {
+		t.Fatalf("RegisterTools() error = %v", err)
+	}
+
+	res, err := connect(t, server).ListTools(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListTools() error = %v", err)
+	}
+	registered := make(map[string]bool)
+	for _, tool := range res.Tools {
+		registered[tool.Name] = true
+	}
+
+	for _, w := range workflows {
+		for _, step := range w.steps {
+			if step.tool != "" && !registered[step.tool] {
+				t.Errorf("workflow %s calls unknown tool %s", w.name, step.tool)
+			}
+		}
+	}
+}
+
+func TestGetPrompt(t *testing.T) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v0.0.0"}, nil)
+	RegisterPrompts(server)
+	session := connect(t, server)
+	ctx := context.Background()
+
+	prompts, err := session.ListPrompts(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListPrompts() error = %v", err)
+	}
+	if len(prompts.Prompts) != len(workflows) {
+		t.Errorf("got %d prompts, want %d", len(prompts.Prompts), len(workflows))
+	}
+
+	res, err := session.GetPrompt(ctx, &mcp.GetPromptParams{
+		Name:      "after_birth",
+		Arguments: map[string]string{"departement": "33", "situation": "parents not married"},
+	})
+	if err != nil {
+		t.Fatalf("GetPrompt() error = %v", err)
+	}
+	text := res.Messages[0].Content.(*mcp.TextContent).Text
+	for _, want := range []string{
+		"My situation: parents not married.",
+		"I live in the département 33.",
+		"1. Call list_life_events",
+		"2. Call get_life_event_details",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("prompt text lacks %q:\n%s", want, text)
+		}
+	}
+
+	// Arguments are optional
+	res, err = session.GetPrompt(ctx, &mcp.GetPromptParams{Name: "first_tax_return"})
+	if err != nil {
+		t.Fatalf("GetPrompt() error = %v", err)
+	}
+	if text := res.Messages[0].Content.(*mcp.TextContent).Text; strings.Contains(text, "My situation") || strings.Contains(text, "I live in") {
+		t.Errorf("prompt text mentions missing arguments:\n%s", text)
+	}
+}
@@ -0,0 +1,172 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/guigui42/mcp-vosdroits/internal/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// URI templates of the documents exposed as resources.
+const (
+	servicePublicTemplate = "servicepublic://{audience}/{id}"
+	impotsTemplate        = "impots://{+path}"
+)
+
+// maxRecentResources is the number of documents read recently that are
+// listed as resources.
+const maxRecentResources = 20
+
+// resourceDocument is a document read through a resource template.
+type resourceDocument struct {
+	Title    string
+	URL      string
+	Markdown string
+	src      noteSource
+}
+
+// resourceReader reads the document identified by a resource URI.
+type resourceReader func(ctx context.Context, uri *url.URL) (*resourceDocument, error)
+
+// registerResources registers the resource templates addressing the fiches
+// of both websites. Every document read is also listed as a resource, up to
+// maxRecent, so that clients can attach it again later. The list belongs to
+// the server and is seen by all its sessions; a maxRecent of 0 disables it.
+func registerResources(server *mcp.Server, httpClient *client.Client, impotsClient *client.ImpotsClient, maxRecent int) {
+	recent := &recentResources{server: server, max: maxRecent}
+
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "service-public-fiche",
+		Title:       "Fiche service-public.gouv.fr",
+		URITemplate: servicePublicTemplate,
+		Description: "A fiche of service-public.gouv.fr as Markdown. audience is particuliers, professionnels or associations; id is the identifier ending the fiche URL, such as F1342, F1342Q or N358 (e.g., servicepublic://particuliers/F1342 for https://www.service-public.gouv.fr/particuliers/vosdroits/F1342).",
+		MIMEType:    "text/markdown",
+	}, recent.handler(servicePublicReader(httpClient)))
+
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "impots-article",
+		Title:       "Article impots.gouv.fr",
+		URITemplate: impotsTemplate,
+		Description: "An article of impots.gouv.fr as Markdown. path is the path of the article URL (e.g., impots://particulier/questions/comment-declarer-mes-revenus for https://www.impots.gouv.fr/particulier/questions/comment-declarer-mes-revenus).",
+		MIMEType:    "text/markdown",
+	}, recent.handler(impotsReader(impotsClient)))
+}
+
+// servicePublicReader reads servicepublic://{audience}/{id} URIs.
+func servicePublicReader(httpClient *client.Client) resourceReader {
+	return func(ctx context.Context, uri *url.URL) (*resourceDocument, error) {
+		audience, err := client.ParseAudience(uri.Host)
+		if err != nil || uri.Host == "" {
+			return nil, mcp.ResourceNotFoundError(uri.String())
+		}
+		scoped := httpClient.ForAudience(audience)
+		ficheURL, err := scoped.FicheURL(strings.Trim(uri.Path, "/"))
+		if err != nil {
+			return nil, mcp.ResourceNotFoundError(uri.String())
+		}
+
+		article, err := scoped.GetArticle(ctx, ficheURL)
+		if err != nil {
+			return nil, err
+		}
+		return &resourceDocument{Title: article.Title, URL: article.URL, Markdown: article.Markdown, src: scoped}, nil
+	}
+}
+
+// impotsReader reads impots://{+path} URIs.
+func impotsReader(impotsClient *client.ImpotsClient) resourceReader {
+	return func(ctx context.Context, uri *url.URL) (*resourceDocument, error) {
+		path := strings.Trim(uri.Host+uri.Path, "/")
+		if path == "" {
+			return nil, mcp.ResourceNotFoundError(uri.String())
+		}
+
+		article, err := impotsClient.GetImpotsArticle(ctx, "/"+path)
+		if err != nil {
+			return nil, err
+		}
+		return &resourceDocument{Title: article.Title, URL: article.URL, Markdown: article.Markdown, src: impotsClient}, nil
+	}
+}
+
+// resourceText renders doc as the Markdown content of a resource.
+func resourceText(doc *resourceDocument) string {
+	text := fmt.Sprintf("# %s\n\nSource: %s", doc.Title, doc.URL)
+	if doc.Markdown != "" {
+		text += "\n\n" + doc.Markdown
+	}
+	return snapshotNote(doc.src, text)
+}
+
+// recentResources lists the documents read most recently as resources of
+// server, dropping the oldest beyond max. Nothing is listed when max is 0.
+type recentResources struct {
+	server *mcp.Server
+	max    int
+
+	mu sync.Mutex
+	// uris holds the listed resources, oldest first.
+	uris []string
+}
+
+// handler returns a resource handler reading documents with read and
+// listing each one read.
+func (r *recentResources) handler(read resourceReader) mcp.ResourceHandler {
+	var h mcp.ResourceHandler
+	h = func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		uri, err := url.Parse(req.Params.URI)
+		if err != nil {
+			return nil, mcp.ResourceNotFoundError(req.Params.URI)
+		}
+
+		doc, err := read(ctx, uri)
+		if err != nil {
+			if errors.Is(err, client.ErrNotFound) {
+				return nil, mcp.ResourceNotFoundError(req.Params.URI)
+			}
+			return nil, fmt.Errorf("failed to read %s: %w", req.Params.URI, err)
+		}
+
+		r.add(&mcp.Resource{
+			URI:         req.Params.URI,
+			Name:        strings.TrimPrefix(req.Params.URI, uri.Scheme+"://"),
+			Title:       doc.Title,
+			Description: doc.URL,
+			MIMEType:    "text/markdown",
+		}, h)
+
+		return &mcp.ReadResourceResult{
+			Contents: []*mcp.ResourceContents{{
+				URI:      req.Params.URI,
+				MIMEType: "text/markdown",
+				Text:     resourceText(doc),
+			}},
+		}, nil
+	}
+	return h
+}
+
+// add lists res, or moves it to the most recent place if already listed.
+func (r *recentResources) add(res *mcp.Resource, h mcp.ResourceHandler) {
+	if r.max <= 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.uris = slices.DeleteFunc(r.uris, func(uri string) bool { return uri == res.URI })
+	r.uris = append(r.uris, res.URI)
+	r.server.AddResource(res, h)
+
+	if len(r.uris) > r.max {
+		r.server.RemoveResources(r.uris[0])
+		r.uris = r.uris[1:]
+	}
+}
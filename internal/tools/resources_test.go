@@ -0,0 +1,183 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/guigui42/mcp-vosdroits/internal/client"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// connect returns a client session of server.
+func connect(t *testing.T, server *mcp.Server) *mcp.ClientSession {
+	t.Helper()
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	if _, err := server.Connect(ctx, serverTransport, nil); err != nil {
+		t.Fatalf("server.Connect() error = %v", err)
+	}
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "v0.0.0"}, nil).Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client.Connect() error = %v", err)
+	}
+	t.Cleanup(func() { session.Close() })
+
+	return session
+}
+
+// newResourceServer serves fixture pages of both websites and returns a
+// server exposing them as resources, listing up to maxRecent of them.
+func newResourceServer(t *testing.T, maxRecent int) *mcp.Server {
+	t.Helper()
+
+	pages := map[string]string{
+		"/particuliers/vosdroits/F1342":            "../client/testdata/service-public/F1342.html",
+		"/particuliers/vosdroits/F1342Q":           "../client/testdata/service-public/F1342.html",
+		"/formulaire/2042/declaration-des-revenus": "../client/testdata/impots/declaration-des-revenus.html",
+	}
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, ok := pages[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		http.ServeFile(w, r, file)
+	}))
+	t.Cleanup(site.Close)
+
+	opts := []client.Option{
+		client.WithBaseURL(site.URL),
+		client.WithCrawl(client.CrawlConfig{Parallelism: 1, IgnoreRobots: true}),
+	}
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v0.0.0"}, nil)
+	registerResources(server, client.New(5*time.Second, opts...), client.NewImpotsClient(5*time.Second, opts...), maxRecent)
+
+	return server
+}
+
+func TestReadResources(t *testing.T) {
+	session := connect(t, newResourceServer(t, maxRecentResources))
+	ctx := context.Background()
+
+	templates, err := session.ListResourceTemplates(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListResourceTemplates() error = %v", err)
+	}
+	if len(templates.ResourceTemplates) != 2 {
+		t.Errorf("got %d resource templates, want 2", len(templates.ResourceTemplates))
+	}
+
+	tests := []struct {
+		uri   string
+		title string
+	}{
+		{"servicepublic://particuliers/F1342", "# Carte d'identité d'un majeur : première demande"},
+		{"servicepublic://particuliers/f1342", "# Carte d'identité d'un majeur : première demande"},
+		{"servicepublic://particuliers/F1342Q", "# Carte d'identité d'un majeur : première demande"},
+		{"impots://formulaire/2042/declaration-des-revenus", "# Formulaire 2042 : déclaration des revenus"},
+	}
+
+	for _, tt := range tests {
+		res, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: tt.uri})
+		if err != nil {
+			t.Fatalf("ReadResource(%s) error = %v", tt.uri, err)
+		}
+		content := res.Contents[0]
+		if content.URI != tt.uri || content.MIMEType != "text/markdown" {
+			t.Errorf("ReadResource(%s) = %s %s", tt.uri, content.URI, content.MIMEType)
+		}
+		if !strings.HasPrefix(content.Text, tt.title+"\n\nSource: http") {
+			t.Errorf("ReadResource(%s) text = %q", tt.uri, content.Text)
+		}
+	}
+
+	// Documents read are listed for clients to attach them again
+	resources, err := session.ListResources(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListResources() error = %v", err)
+	}
+	if len(resources.Resources) != 4 {
+		t.Fatalf("got %d resources, want 4", len(resources.Resources))
+	}
+	for _, r := range resources.Resources {
+		if r.URI == "servicepublic://particuliers/F1342" && (r.Name != "particuliers/F1342" || !strings.HasSuffix(r.Description, "/particuliers/vosdroits/F1342")) {
+			t.Errorf("resource = %+v", r)
+		}
+	}
+}
+
+func TestReadResourceNotFound(t *testing.T) {
+	session := connect(t, newResourceServer(t, maxRecentResources))
+
+	for _, uri := range []string{
+		"servicepublic://particuliers/F9999",
+		"servicepublic://entreprises/F1342",
+		"servicepublic://particuliers/..",
+		"servicepublic://particuliers/X1342",
+		"impots://",
+	} {
+		if _, err := session.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: uri}); err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Errorf("ReadResource(%s) error = %v, want not found", uri, err)
+		}
+	}
+
+	resources, err := session.ListResources(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListResources() error = %v", err)
+	}
+	if len(resources.Resources) != 0 {
+		t.Errorf("ListResources() = %d resources, want none", len(resources.Resources))
+	}
+}
+
+func TestReadResourcesUnlisted(t *testing.T) {
+	session := connect(t, newResourceServer(t, 0))
+	ctx := context.Background()
+
+	if _, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "servicepublic://particuliers/F1342"}); err != nil {
+		t.Fatalf("ReadResource() error = %v", err)
+	}
+
+	// Without a recent list, documents read are not shared with other sessions
+	resources, err := session.ListResources(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListResources() error = %v", err)
+	}
+	if len(resources.Resources) != 0 {
+		t.Errorf("ListResources() = %d resources, want none", len(resources.Resources))
+	}
+}
+
+func TestRecentResourcesLimit(t *testing.T) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "v0.0.0"}, nil)
+	recent := &recentResources{server: server, max: 2}
+	handler := func(context.Context, *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		return nil, nil
+	}
+
+	for i := 1; i <= 3; i++ {
+		recent.add(&mcp.Resource{URI: fmt.Sprintf("servicepublic://particuliers/F%d", i), Name: "fiche"}, handler)
+	}
+	// Reading a resource again makes it the most recent
+	recent.add(&mcp.Resource{URI: "servicepublic://particuliers/F2", Name: "fiche"}, handler)
+	recent.add(&mcp.Resource{URI: "servicepublic://particuliers/F4", Name: "fiche"}, handler)
+
+	resources, err := connect(t, server).ListResources(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListResources() error = %v", err)
+	}
+	var uris []string
+	for _, r := range resources.Resources {
+		uris = append(uris, r.URI)
+	}
+	if want := "servicepublic://particuliers/F2 servicepublic://particuliers/F4"; strings.Join(uris, " ") != want {
+		t.Errorf("ListResources() = %v, want %s", uris, want)
+	}
+}
@@ -4,16 +4,56 @@ package tools
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/guigui42/mcp-vosdroits/internal/client"
 	"github.com/guigui42/mcp-vosdroits/internal/config"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
-// RegisterTools registers all available MCP tools with the server.
+// RegisterTools registers all available MCP tools with the server, along
+// with the resources exposing fiches as documents.
 func RegisterTools(server *mcp.Server, cfg *config.Config) error {
+	// Share the cache settings between both clients
+	cacheOpt := client.WithCache(client.CacheConfig{
+		MaxEntries: cfg.CacheMaxEntries,
+		ListTTL:    cfg.CacheListTTL,
+		SearchTTL:  cfg.CacheSearchTTL,
+		ArticleTTL: cfg.CacheArticleTTL,
+	})
+
+	opts := []client.Option{cacheOpt, client.WithCrawl(client.CrawlConfig{
+		UserAgent:    cfg.CrawlUserAgent,
+		Delay:        cfg.CrawlDelay,
+		Parallelism:  cfg.CrawlParallelism,
+		IgnoreRobots: !cfg.RespectRobotsTxt,
+	}), client.WithLimits(client.LimitConfig{
+		MaxPageSize:     cfg.MaxPageSize,
+		MaxDocumentSize: cfg.MaxDocumentSize,
+	}), client.WithRetry(client.RetryConfig{
+		MaxAttempts: cfg.RetryMaxAttempts,
+		BaseDelay:   cfg.RetryBaseDelay,
+		MaxDelay:    cfg.RetryMaxDelay,
+	}), client.WithBreaker(client.BreakerConfig{
+		Threshold: cfg.BreakerThreshold,
+		Cooldown:  cfg.BreakerCooldown,
+	})}
+	if cfg.PageCacheDir != "" {
+		opts = append(opts, client.WithPageCache(cfg.PageCacheDir, cfg.PageCacheTTL))
+	}
+
+	// Serve every tool from an offline archive when one is configured
+	if cfg.SnapshotFile != "" {
+		snap, err := client.LoadSnapshot(cfg.SnapshotFile)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, client.WithSnapshot(snap))
+	}
+
 	// Create HTTP client for service-public.gouv.fr
-	httpClient := client.New(cfg.HTTPTimeout)
+	httpClient := client.New(cfg.HTTPTimeout, opts...)
 
 	// Register search_procedures tool
 	if err := registerSearchProcedures(server, httpClient); err != nil {
@@ -30,6 +70,11 @@ func RegisterTools(server *mcp.Server, cfg *config.Config) error {
 		return fmt.Errorf("failed to register list_categories: %w", err)
 	}
 
+	// Register browse_category tool
+	if err := registerBrowseCategory(server, httpClient); err != nil {
+		return fmt.Errorf("failed to register browse_category: %w", err)
+	}
+
 	// Register life events tools
 	if err := registerListLifeEvents(server, httpClient); err != nil {
 		return fmt.Errorf("failed to register list_life_events: %w", err)
@@ -39,26 +84,103 @@ func RegisterTools(server *mcp.Server, cfg *config.Config) error {
 		return fmt.Errorf("failed to register get_life_event_details: %w", err)
 	}
 
+	// Register Cerfa form tools
+	if err := registerListForms(server, httpClient); err != nil {
+		return fmt.Errorf("failed to register list_forms: %w", err)
+	}
+
+	if err := registerGetForm(server, httpClient); err != nil {
+		return fmt.Errorf("failed to register get_form: %w", err)
+	}
+
 	// Create HTTP client for impots.gouv.fr
-	impotsClient := client.NewImpotsClient(cfg.HTTPTimeout)
+	impotsClient := client.NewImpotsClient(cfg.HTTPTimeout, opts...)
 
 	// Register impots.gouv.fr tools
 	if err := RegisterImpotsTools(server, impotsClient); err != nil {
 		return fmt.Errorf("failed to register impots tools: %w", err)
 	}
 
+	// Register get_document tool, serving PDFs of both websites
+	if err := registerGetDocument(server, httpClient, impotsClient); err != nil {
+		return fmt.Errorf("failed to register get_document: %w", err)
+	}
+
+	// Expose fiches as resources, sharing the clients of the tools. Recently
+	// read documents are listed on the server, which every HTTP session
+	// shares, so they are only listed for the single client of stdio
+	maxRecent := maxRecentResources
+	if cfg.HTTPPort != "" {
+		maxRecent = 0
+	}
+	registerResources(server, httpClient, impotsClient, maxRecent)
+
 	return nil
 }
 
 // SearchProceduresInput defines the input schema for search_procedures.
 type SearchProceduresInput struct {
-	Query string `json:"query" jsonschema:"Search query for procedures (e.g. 'carte d'identité' or 'passport renewal')"`
-	Limit int    `json:"limit,omitempty" jsonschema:"Maximum number of results to return (1-100), default 10"`
+	Query    string `json:"query" jsonschema:"Search query for procedures (e.g. 'carte d'identité' or 'passport renewal')"`
+	Limit    int    `json:"limit,omitempty" jsonschema:"Maximum number of results to return (1-100), default 10"`
+	Audience string `json:"audience,omitempty" jsonschema:"Audience whose space to use: particuliers (default, individuals), professionnels (businesses, on entreprendre.service-public.gouv.fr) or associations"`
+	Cursor   string `json:"cursor,omitempty" jsonschema:"Cursor returned as next_cursor by a previous call, to get the following page of results. The query and audience must be the same."`
+}
+
+// audienceClient returns httpClient scoped to the audience input.
+func audienceClient(httpClient *client.Client, audience string) (*client.Client, error) {
+	a, err := client.ParseAudience(audience)
+	if err != nil {
+		return nil, err
+	}
+	return httpClient.ForAudience(a), nil
 }
 
 // SearchProceduresOutput defines the output schema for search_procedures.
 type SearchProceduresOutput struct {
-	Results []ProcedureResult `json:"results" jsonschema:"List of matching procedures. Each result includes a URL that can be used with the get_article tool to retrieve full details."`
+	Results      []ProcedureResult `json:"results" jsonschema:"List of matching procedures. Each result includes a URL that can be used with the get_article tool to retrieve full details."`
+	TotalResults int               `json:"total_results,omitempty" jsonschema:"Total number of matches reported by the website, when known"`
+	Page         int               `json:"page" jsonschema:"Number of this page of results, starting at 1"`
+	NextCursor   string            `json:"next_cursor,omitempty" jsonschema:"Pass as cursor to get the next page of results. Absent on the last page."`
+	EmptyReason  string            `json:"empty_reason,omitempty" jsonschema:"Why there are no results: no_match (nothing matches the query), site_error (the website could not be reached), parse_failure (the results page could not be read) or offline (the search is not in the offline snapshot being served)"`
+	Suggestions  []string          `json:"suggestions,omitempty" jsonschema:"Alternative queries suggested by the website, such as spelling corrections or related searches"`
+}
+
+// emptySearchMessage explains to the model why a search on site returned no
+// results and what to try next.
+func emptySearchMessage(site, query string, reason client.EmptyReason, suggestions []string, err error) string {
+	var message string
+	switch reason {
+	case client.EmptySiteError:
+		message = fmt.Sprintf("The search on %s failed, so it is unknown whether anything matches '%s'. The website may be down or slow: try again later.", site, query)
+		if err != nil {
+			message += fmt.Sprintf(" Error: %v.", err)
+		}
+	case client.EmptyOffline:
+		message = fmt.Sprintf("Searching %s is unavailable offline: the server answers from a snapshot that does not include the search for '%s'. Do not retry; browse the categories (list_life_events, list_categories, list_impots_categories) to reach the pages of the snapshot instead.", site, query)
+	case client.EmptyParseFailure:
+		message = fmt.Sprintf("The search page of %s was fetched for '%s' but no results could be read from it; the website layout may have changed. Do not assume that nothing matches.", site, query)
+	default:
+		message = fmt.Sprintf("No results match '%s' on %s.", query, site)
+	}
+	if len(suggestions) > 0 {
+		message += fmt.Sprintf(" The website suggests: %s.", strings.Join(suggestions, ", "))
+	} else if reason == client.EmptyNoMatch {
+		message += " Try fewer or more general search terms."
+	}
+	return message
+}
+
+// paginationNote describes the position of a page of results for the tool
+// text output.
+func paginationNote(total, page int, nextCursor string) string {
+	var note string
+	if total > 0 {
+		note = fmt.Sprintf(" The website reports %d results in total (page %d).", total, page)
+	}
+	if nextCursor != "" {
+		note += fmt.Sprintf(" More results are available: call again with cursor %q.", nextCursor)
+	}
+	return note
 }
 
 // ProcedureResult represents a single procedure search result.
@@ -71,7 +193,7 @@ type ProcedureResult struct {
 func registerSearchProcedures(server *mcp.Server, httpClient *client.Client) error {
 	tool := &mcp.Tool{
 		Name:        "search_procedures",
-		Description: "Search for SPECIFIC administrative procedures on service-public.gouv.fr (e.g., 'passport renewal', 'driver's license'). WARNING: For MAJOR LIFE SITUATIONS (buying house, marriage, birth, death, moving, job change, retirement), you MUST use list_life_events + get_life_event_details FIRST before trying this tool. Only use search_procedures if the life event details are insufficient or for simple administrative tasks only. Returns URLs and brief descriptions. For detailed information about any procedure, use the get_article tool with the returned URLs.",
+		Description: "Search administrative procedures on service-public.gouv.fr (e.g., 'passport renewal', 'driver's license'). Returns the titles, URLs and brief descriptions of the matching fiches; retrieve their full content with get_article. For major life situations (marriage, birth, death, moving, buying a house, retirement), try list_life_events first.",
 	}
 
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input SearchProceduresInput) (*mcp.CallToolResult, SearchProceduresOutput, error) {
@@ -82,18 +204,27 @@ func registerSearchProcedures(server *mcp.Server, httpClient *client.Client) err
 
 		// Validate input
 		if input.Query == "" {
-			return nil, SearchProceduresOutput{}, fmt.Errorf("query cannot be empty")
+			return nil, SearchProceduresOutput{}, invalidInput("query cannot be empty")
+		}
+		scoped, err := audienceClient(httpClient, input.Audience)
+		if err != nil {
+			return nil, SearchProceduresOutput{}, err
 		}
 
-		// TODO: Implement actual search logic using client
-		results, err := httpClient.SearchProcedures(ctx, input.Query, input.Limit)
+		page, err := scoped.SearchProceduresPage(ctx, input.Query, input.Limit, input.Cursor)
 		if err != nil {
 			return nil, SearchProceduresOutput{}, fmt.Errorf("search failed: %w", err)
 		}
+		results := page.Results
 
 		// Convert client results to output format
 		output := SearchProceduresOutput{
-			Results: make([]ProcedureResult, len(results)),
+			Results:      make([]ProcedureResult, len(results)),
+			TotalResults: page.Total,
+			Page:         page.Page,
+			NextCursor:   page.NextCursor,
+			EmptyReason:  string(page.EmptyReason),
+			Suggestions:  page.Suggestions,
 		}
 		for i, r := range results {
 			output.Results[i] = ProcedureResult{
@@ -107,31 +238,210 @@ func registerSearchProcedures(server *mcp.Server, httpClient *client.Client) err
 		message := fmt.Sprintf("Found %d procedures matching '%s'. ", len(results), input.Query)
 		if len(results) > 0 {
 			message += "Use the get_article tool with any of the returned URLs to retrieve complete details about a specific procedure."
+			message += paginationNote(page.Total, page.Page, page.NextCursor)
+		} else {
+			message = emptySearchMessage(scoped.Name(), input.Query, page.EmptyReason, page.Suggestions, page.Err)
 		}
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{
-					Text: message,
+					Text: snapshotNote(scoped, message),
 				},
 			},
 		}, output, nil
 	}
 
-	mcp.AddTool(server, tool, handler)
-	return nil
+	return addTool(server, tool, handler)
 }
 
 // GetArticleInput defines the input schema for get_article.
 type GetArticleInput struct {
-	URL string `json:"url" jsonschema:"URL of the article to retrieve (typically from search_procedures results)"`
+	URL      string `json:"url" jsonschema:"URL of the article to retrieve (typically from search_procedures results)"`
+	Format   string `json:"format,omitempty" jsonschema:"Format of the returned content: text (default) for plain text, or markdown to keep headings, lists, bold text, links and tables"`
+	Audience string `json:"audience,omitempty" jsonschema:"Audience whose space relative URLs belong to: particuliers (default, individuals), professionnels (businesses, on entreprendre.service-public.gouv.fr) or associations"`
+}
+
+// Content formats accepted by the format input of the article tools.
+const (
+	formatText     = "text"
+	formatMarkdown = "markdown"
+)
+
+// validateFormat checks the format input of an article tool.
+func validateFormat(format string) error {
+	switch format {
+	case "", formatText, formatMarkdown:
+		return nil
+	}
+	return invalidInput("format must be %q or %q, got %q", formatText, formatMarkdown, format)
+}
+
+// selectContent returns the Markdown rendering of a page when format asks for
+// it and one is available, and the plain text otherwise.
+func selectContent(format, text, markdown string) string {
+	if format == formatMarkdown && markdown != "" {
+		return markdown
+	}
+	return text
 }
 
 // GetArticleOutput defines the output schema for get_article.
 type GetArticleOutput struct {
-	Title   string `json:"title" jsonschema:"Title of the article"`
-	Content string `json:"content" jsonschema:"Full content of the article"`
-	URL     string `json:"url" jsonschema:"URL of the article"`
+	Title    string                 `json:"title" jsonschema:"Title of the article"`
+	Content  string                 `json:"content" jsonschema:"Full content of the article"`
+	URL      string                 `json:"url" jsonschema:"URL of the article"`
+	Sections []ArticleSectionOutput `json:"sections,omitempty" jsonschema:"Section tree of the article in document order, each section holding its subsections. Use the section number to cite a specific section."`
+	Tables   []TableOutput          `json:"tables,omitempty" jsonschema:"Tables of the article, such as fees, income thresholds or deadlines"`
+	Links    []LinkOutput           `json:"links,omitempty" jsonschema:"Outbound links of the article. Follow fiche and question links with get_article instead of guessing URLs."`
+}
+
+// LinkOutput represents a link found in an article.
+type LinkOutput struct {
+	Text string `json:"text" jsonschema:"Text of the link"`
+	URL  string `json:"url" jsonschema:"Absolute URL of the link"`
+	Kind string `json:"kind" jsonschema:"Kind of target: fiche (service-public fiche pratique, F-page), category (N-page), question (question-réponse), teleservice (online procedure), form (Cerfa form or PDF), law (legal text such as Légifrance) or other"`
+}
+
+// linksOutput converts client links to their output format.
+func linksOutput(links []client.Link) []LinkOutput {
+	if len(links) == 0 {
+		return nil
+	}
+	output := make([]LinkOutput, len(links))
+	for i, l := range links {
+		output[i] = LinkOutput{
+			Text: l.Text,
+			URL:  l.URL,
+			Kind: string(l.Kind),
+		}
+	}
+	return output
+}
+
+// TableOutput represents a table extracted from a page.
+type TableOutput struct {
+	Caption string     `json:"caption,omitempty" jsonschema:"Caption of the table"`
+	Headers []string   `json:"headers,omitempty" jsonschema:"Column headings"`
+	Rows    [][]string `json:"rows" jsonschema:"Rows of the table, one array of cell values per row"`
+}
+
+// tablesOutput converts client tables to their output format.
+func tablesOutput(tables []client.Table) []TableOutput {
+	if len(tables) == 0 {
+		return nil
+	}
+	output := make([]TableOutput, len(tables))
+	for i, t := range tables {
+		output[i] = TableOutput{
+			Caption: t.Caption,
+			Headers: t.Headers,
+			Rows:    t.Rows,
+		}
+	}
+	return output
+}
+
+// ArticleSectionOutput represents a main section of an article. Sections
+// come from h2 to h4 headings, so the tree is at most three levels deep: the
+// output schema cannot describe a recursive type.
+type ArticleSectionOutput struct {
+	Number      string                    `json:"number" jsonschema:"Position of the section in the article outline (e.g., 2 for the second section)"`
+	Title       string                    `json:"title" jsonschema:"Section heading"`
+	Paragraphs  []string                  `json:"paragraphs,omitempty" jsonschema:"Paragraphs of the section, excluding its subsections"`
+	Lists       [][]string                `json:"lists,omitempty" jsonschema:"Bullet and numbered lists of the section, one array of items per list"`
+	Subsections []ArticleSubsectionOutput `json:"subsections,omitempty" jsonschema:"Subsections of the section, in document order"`
+}
+
+// ArticleSubsectionOutput represents a subsection of an article section.
+type ArticleSubsectionOutput struct {
+	Number      string                       `json:"number" jsonschema:"Position of the subsection in the article outline (e.g., 2.1 for the first subsection of the second section)"`
+	Title       string                       `json:"title" jsonschema:"Subsection heading"`
+	Paragraphs  []string                     `json:"paragraphs,omitempty" jsonschema:"Paragraphs of the subsection, excluding its own subsections"`
+	Lists       [][]string                   `json:"lists,omitempty" jsonschema:"Bullet and numbered lists of the subsection, one array of items per list"`
+	Subsections []ArticleSubsubsectionOutput `json:"subsections,omitempty" jsonschema:"Subsections of the subsection, in document order"`
+}
+
+// ArticleSubsubsectionOutput represents the innermost level of article
+// sections.
+type ArticleSubsubsectionOutput struct {
+	Number     string     `json:"number" jsonschema:"Position of the subsection in the article outline (e.g., 2.1.3)"`
+	Title      string     `json:"title" jsonschema:"Subsection heading"`
+	Paragraphs []string   `json:"paragraphs,omitempty" jsonschema:"Paragraphs of the subsection"`
+	Lists      [][]string `json:"lists,omitempty" jsonschema:"Bullet and numbered lists of the subsection, one array of items per list"`
+}
+
+// sectionsOutput converts the section tree of an article to its output
+// format, numbering each section after its position in the outline.
+func sectionsOutput(sections []client.ArticleSection) []ArticleSectionOutput {
+	if len(sections) == 0 {
+		return nil
+	}
+	output := make([]ArticleSectionOutput, len(sections))
+	for i, s := range sections {
+		number := fmt.Sprintf("%d", i+1)
+		output[i] = ArticleSectionOutput{
+			Number:      number,
+			Title:       s.Title,
+			Paragraphs:  s.Paragraphs,
+			Lists:       s.Lists,
+			Subsections: subsectionsOutput(s.Sections, number+"."),
+		}
+	}
+	return output
+}
+
+// subsectionsOutput converts the subsections of a section numbered prefix.
+func subsectionsOutput(sections []client.ArticleSection, prefix string) []ArticleSubsectionOutput {
+	if len(sections) == 0 {
+		return nil
+	}
+	output := make([]ArticleSubsectionOutput, len(sections))
+	for i, s := range sections {
+		number := fmt.Sprintf("%s%d", prefix, i+1)
+		output[i] = ArticleSubsectionOutput{
+			Number:      number,
+			Title:       s.Title,
+			Paragraphs:  s.Paragraphs,
+			Lists:       s.Lists,
+			Subsections: subsubsectionsOutput(s.Sections, number+"."),
+		}
+	}
+	return output
+}
+
+// subsubsectionsOutput converts the innermost subsections of a subsection
+// numbered prefix.
+func subsubsectionsOutput(sections []client.ArticleSection, prefix string) []ArticleSubsubsectionOutput {
+	if len(sections) == 0 {
+		return nil
+	}
+	output := make([]ArticleSubsubsectionOutput, len(sections))
+	for i, s := range sections {
+		output[i] = ArticleSubsubsectionOutput{
+			Number:     fmt.Sprintf("%s%d", prefix, i+1),
+			Title:      s.Title,
+			Paragraphs: s.Paragraphs,
+			Lists:      s.Lists,
+		}
+	}
+	return output
+}
+
+// sectionsOutline lists the numbers and titles of sections, indented by
+// depth, so that the model can cite them.
+func sectionsOutline(sections []ArticleSectionOutput) string {
+	var b strings.Builder
+	for _, s := range sections {
+		fmt.Fprintf(&b, "%s. %s\n", s.Number, s.Title)
+		for _, sub := range s.Subsections {
+			fmt.Fprintf(&b, "  %s. %s\n", sub.Number, sub.Title)
+			for _, subsub := range sub.Subsections {
+				fmt.Fprintf(&b, "    %s. %s\n", subsub.Number, subsub.Title)
+			}
+		}
+	}
+	return b.String()
 }
 
 func registerGetArticle(server *mcp.Server, httpClient *client.Client) error {
@@ -142,40 +452,47 @@ func registerGetArticle(server *mcp.Server, httpClient *client.Client) error {
 
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetArticleInput) (*mcp.CallToolResult, GetArticleOutput, error) {
 		if input.URL == "" {
-			return nil, GetArticleOutput{}, fmt.Errorf("url cannot be empty")
+			return nil, GetArticleOutput{}, invalidInput("url cannot be empty")
+		}
+		if err := validateFormat(input.Format); err != nil {
+			return nil, GetArticleOutput{}, err
+		}
+		scoped, err := audienceClient(httpClient, input.Audience)
+		if err != nil {
+			return nil, GetArticleOutput{}, err
 		}
 
 		// TODO: Implement actual article retrieval using client
-		article, err := httpClient.GetArticle(ctx, input.URL)
+		article, err := scoped.GetArticle(ctx, input.URL)
 		if err != nil {
-			// Return a clear error message that discourages retrying the same URL
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					&mcp.TextContent{
-						Text: fmt.Sprintf("ERROR: Unable to retrieve article from %s. Reason: %v\n\nDo NOT retry this same URL. Instead, inform the user that this specific article could not be retrieved and suggest they visit the URL directly in their browser, or try searching for alternative procedures.", input.URL, err),
-					},
-				},
-				IsError: true,
-			}, GetArticleOutput{}, fmt.Errorf("failed to get article from %s: %w", input.URL, err)
+			return nil, GetArticleOutput{}, fmt.Errorf("failed to get article from %s: %w", input.URL, err)
 		}
 
 		output := GetArticleOutput{
-			Title:   article.Title,
-			Content: article.Content,
-			URL:     article.URL,
+			Title:    article.Title,
+			Content:  selectContent(input.Format, article.Content, article.Markdown),
+			URL:      article.URL,
+			Sections: sectionsOutput(article.Sections),
+			Tables:   tablesOutput(article.Tables),
+			Links:    linksOutput(article.Links),
+		}
+
+		// Outline the sections so they can be cited by number
+		outline := ""
+		if len(output.Sections) > 0 {
+			outline = "\n\nSections:\n" + sectionsOutline(output.Sections)
 		}
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{
-					Text: fmt.Sprintf("Retrieved article: %s\n\nSource: %s\n\nIMPORTANT: Always provide this source URL to the user so they can access the original article.", article.Title, article.URL),
+					Text: snapshotNote(scoped, fmt.Sprintf("Retrieved article: %s%s\n\nSource: %s\n\nIMPORTANT: Always provide this source URL to the user so they can access the original article.", article.Title, outline, article.URL)),
 				},
 			},
 		}, output, nil
 	}
 
-	mcp.AddTool(server, tool, handler)
-	return nil
+	return addTool(server, tool, handler)
 }
 
 // ListCategoriesOutput defines the output schema for list_categories.
@@ -187,9 +504,12 @@ type ListCategoriesOutput struct {
 type Category struct {
 	Name        string `json:"name" jsonschema:"Name of the category"`
 	Description string `json:"description" jsonschema:"Description of the category"`
+	URL         string `json:"url,omitempty" jsonschema:"URL of the category page. Use it with browse_category to list its subthemes and fiches."`
 }
 
-type ListCategoriesInput struct{}
+type ListCategoriesInput struct {
+	Audience string `json:"audience,omitempty" jsonschema:"Audience whose space to use: particuliers (default, individuals), professionnels (businesses, on entreprendre.service-public.gouv.fr) or associations"`
+}
 
 func registerListCategories(server *mcp.Server, httpClient *client.Client) error {
 	tool := &mcp.Tool{
@@ -199,7 +519,12 @@ func registerListCategories(server *mcp.Server, httpClient *client.Client) error
 
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ListCategoriesInput) (*mcp.CallToolResult, ListCategoriesOutput, error) {
 		// TODO: Implement actual category listing using client
-		categories, err := httpClient.ListCategories(ctx)
+		scoped, err := audienceClient(httpClient, input.Audience)
+		if err != nil {
+			return nil, ListCategoriesOutput{}, err
+		}
+
+		categories, err := scoped.ListCategories(ctx)
 		if err != nil {
 			return nil, ListCategoriesOutput{}, fmt.Errorf("failed to list categories: %w", err)
 		}
@@ -211,43 +536,107 @@ func registerListCategories(server *mcp.Server, httpClient *client.Client) error
 			output.Categories[i] = Category{
 				Name:        c.Name,
 				Description: c.Description,
+				URL:         c.URL,
 			}
 		}
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{
-					Text: fmt.Sprintf("Found %d categories", len(categories)),
+					Text: snapshotNote(scoped, fmt.Sprintf("Found %d categories", len(categories))),
 				},
 			},
 		}, output, nil
 	}
 
-	mcp.AddTool(server, tool, handler)
-	return nil
+	return addTool(server, tool, handler)
+}
+
+// BrowseCategoryInput defines the input schema for browse_category.
+type BrowseCategoryInput struct {
+	URL      string `json:"url" jsonschema:"URL of a category page with N-prefix (e.g., /particuliers/vosdroits/N19808), from list_categories or a previous browse_category call"`
+	Audience string `json:"audience,omitempty" jsonschema:"Audience whose space relative URLs belong to: particuliers (default, individuals), professionnels (businesses, on entreprendre.service-public.gouv.fr) or associations"`
+}
+
+// BrowseCategoryOutput defines the output schema for browse_category.
+type BrowseCategoryOutput struct {
+	Title         string       `json:"title" jsonschema:"Title of the category"`
+	URL           string       `json:"url" jsonschema:"URL of the category page"`
+	Description   string       `json:"description,omitempty" jsonschema:"Introduction of the category"`
+	Parents       []LinkOutput `json:"parents,omitempty" jsonschema:"Enclosing categories, from the broadest to the closest"`
+	Subcategories []LinkOutput `json:"subcategories,omitempty" jsonschema:"Subthemes of the category. Browse them with browse_category."`
+	Fiches        []LinkOutput `json:"fiches,omitempty" jsonschema:"Fiches and questions-réponses of the category. Retrieve them with get_article."`
+}
+
+func registerBrowseCategory(server *mcp.Server, httpClient *client.Client) error {
+	tool := &mcp.Tool{
+		Name:        "browse_category",
+		Description: "Browse the service-public.gouv.fr taxonomy. Takes a category page URL (N-prefix, e.g. /particuliers/vosdroits/N19808, as returned by list_categories) and returns its subthemes (more N-pages to browse) and its fiches (F-pages to retrieve with get_article).",
+	}
+
+	handler := func(ctx context.Context, req *mcp.CallToolRequest, input BrowseCategoryInput) (*mcp.CallToolResult, BrowseCategoryOutput, error) {
+		if input.URL == "" {
+			return nil, BrowseCategoryOutput{}, invalidInput("url cannot be empty")
+		}
+		scoped, err := audienceClient(httpClient, input.Audience)
+		if err != nil {
+			return nil, BrowseCategoryOutput{}, err
+		}
+
+		page, err := scoped.BrowseCategory(ctx, input.URL)
+		if err != nil {
+			return nil, BrowseCategoryOutput{}, withHint(fmt.Errorf("failed to browse category %s: %w", input.URL, err), "Use list_categories to get valid category URLs (N-prefix), or get_article for fiches (F-prefix).")
+		}
+
+		output := BrowseCategoryOutput{
+			Title:         page.Title,
+			URL:           page.URL,
+			Description:   page.Description,
+			Parents:       linksOutput(page.Parents),
+			Subcategories: linksOutput(page.Subcategories),
+			Fiches:        linksOutput(page.Fiches),
+		}
+
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				&mcp.TextContent{
+					Text: snapshotNote(scoped, fmt.Sprintf("Category: %s\n\nFound %d subcategories (browse them with browse_category) and %d fiches (retrieve them with get_article).\n\nSource: %s", page.Title, len(page.Subcategories), len(page.Fiches), page.URL)),
+				},
+			},
+		}, output, nil
+	}
+
+	return addTool(server, tool, handler)
 }
 
 // ListLifeEventsOutput defines the output schema for list_life_events.
 type ListLifeEventsOutput struct {
-	Events []LifeEventInfo `json:"events" jsonschema:"List of available life events - THESE ARE ONLY TITLES AND URLS. You MUST call get_life_event_details with a URL to get actual procedures and detailed information."`
+	Events []LifeEventInfo `json:"events" jsonschema:"Available life events, with their titles and URLs only"`
 }
 
 // LifeEventInfo represents a life event.
 type LifeEventInfo struct {
-	Title string `json:"title" jsonschema:"Title of the life event - this is ONLY the title, not the actual content"`
-	URL   string `json:"url" jsonschema:"IMPORTANT: URL to pass to get_life_event_details tool to retrieve actual procedures and information. Must be used exactly as-is - do not modify."`
+	Title string `json:"title" jsonschema:"Title of the life event"`
+	URL   string `json:"url" jsonschema:"URL of the life event, to pass unchanged to get_life_event_details"`
 }
 
-type ListLifeEventsInput struct{}
+type ListLifeEventsInput struct {
+	Audience string `json:"audience,omitempty" jsonschema:"Audience whose space to use. Only particuliers (default, individuals) publishes life events."`
+}
 
 func registerListLifeEvents(server *mcp.Server, httpClient *client.Client) error {
 	tool := &mcp.Tool{
 		Name:        "list_life_events",
-		Description: "PRIMARY TOOL for major life situations in France (e.g., 'acheter une maison', 'buying a house', 'getting married', 'having a baby', 'death of relative', 'moving', 'changing jobs', 'retirement', 'divorce'). Use THIS tool first for comprehensive guidance on major life events. Returns list of available life events with titles and URLs. MANDATORY NEXT STEP: You MUST call get_life_event_details with one of the returned URLs to get actual procedures - DO NOT skip this step and jump to search_procedures. Only use search_procedures if get_life_event_details doesn't provide enough information.",
+		Description: "List the life events of service-public.gouv.fr, guides to major life situations in France (e.g., buying a house, getting married, having a baby, death of a relative, moving, retirement). Returns their titles and URLs only; get_life_event_details retrieves their content. Prefer these guides over search_procedures for major life situations.",
 	}
 
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input ListLifeEventsInput) (*mcp.CallToolResult, ListLifeEventsOutput, error) {
-		events, err := httpClient.ListLifeEvents(ctx)
+		scoped, err := audienceClient(httpClient, input.Audience)
+		if err != nil {
+			return nil, ListLifeEventsOutput{}, err
+		}
+
+		events, err := scoped.ListLifeEvents(ctx)
 		if err != nil {
 			return nil, ListLifeEventsOutput{}, fmt.Errorf("failed to list life events: %w", err)
 		}
@@ -271,19 +660,20 @@ func registerListLifeEvents(server *mcp.Server, httpClient *client.Client) error
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{
-					Text: fmt.Sprintf("Found %d life events (titles only).\n\nMANDATORY NEXT STEP: You MUST immediately call get_life_event_details with the most relevant URL from the list below. DO NOT call search_procedures yet - the life event details contain comprehensive information organized by topic that will likely answer the user's question.\n\nOnly use search_procedures if get_life_event_details doesn't provide sufficient information.\n\nIMPORTANT: Use the EXACT URL from the results - these are fiche pratique URLs (F-prefix). Do NOT modify or substitute with category URLs (N-prefix).%s", len(events), eventsList),
+					Text: snapshotNote(scoped, fmt.Sprintf("Found %d life events (titles only; retrieve their content with get_life_event_details).%s", len(events), eventsList)),
 				},
 			},
 		}, output, nil
 	}
 
-	mcp.AddTool(server, tool, handler)
-	return nil
+	return addTool(server, tool, handler)
 }
 
 // GetLifeEventDetailsInput defines the input schema for get_life_event_details.
 type GetLifeEventDetailsInput struct {
-	URL string `json:"url" jsonschema:"required,EXACT URL from list_life_events results. Must be a fiche pratique URL with F-prefix like https://www.service-public.gouv.fr/particuliers/vosdroits/F16225. Do NOT use category URLs with N-prefix or modify the URL."`
+	URL      string `json:"url" jsonschema:"required,URL of the life event as returned by list_life_events, an F-page such as https://www.service-public.gouv.fr/particuliers/vosdroits/F16225"`
+	Format   string `json:"format,omitempty" jsonschema:"Format of the returned content: text (default) for plain text, or markdown to keep headings, lists, bold text, links and tables"`
+	Audience string `json:"audience,omitempty" jsonschema:"Audience whose space relative URLs belong to: particuliers (default, individuals), professionnels (businesses, on entreprendre.service-public.gouv.fr) or associations"`
 }
 
 // GetLifeEventDetailsOutput defines the output schema for get_life_event_details.
@@ -296,31 +686,32 @@ type GetLifeEventDetailsOutput struct {
 
 // LifeEventSectionOutput represents a section within a life event.
 type LifeEventSectionOutput struct {
-	Title   string `json:"title" jsonschema:"Section title (e.g., Santé, État civil, Emploi-Travail)"`
-	Content string `json:"content" jsonschema:"Detailed content for this section"`
+	Title   string        `json:"title" jsonschema:"Section title (e.g., Santé, État civil, Emploi-Travail)"`
+	Content string        `json:"content" jsonschema:"Detailed content for this section"`
+	Tables  []TableOutput `json:"tables,omitempty" jsonschema:"Tables of this section, such as fees, income thresholds or deadlines"`
 }
 
 func registerGetLifeEventDetails(server *mcp.Server, httpClient *client.Client) error {
 	tool := &mcp.Tool{
 		Name:        "get_life_event_details",
-		Description: "Retrieve detailed information about a specific life event from service-public.gouv.fr. CRITICAL: Use the EXACT URL from list_life_events results (fiche pratique F-URLs only, like /F16225). Do NOT use category URLs (N-prefix like /N20020). Provides comprehensive guidance organized by topic (Health, Civil Status, Employment, etc.) for major life situations.",
+		Description: "Retrieve a life event of service-public.gouv.fr from its URL, as returned by list_life_events (an F-page such as /F16225, not an N-prefixed category). Returns its introduction and its sections organized by topic (Health, Civil Status, Employment, etc.). Use it before search_procedures for major life situations.",
 	}
 
 	handler := func(ctx context.Context, req *mcp.CallToolRequest, input GetLifeEventDetailsInput) (*mcp.CallToolResult, GetLifeEventDetailsOutput, error) {
 		if input.URL == "" {
-			return nil, GetLifeEventDetailsOutput{}, fmt.Errorf("url cannot be empty")
+			return nil, GetLifeEventDetailsOutput{}, invalidInput("url cannot be empty")
+		}
+		if err := validateFormat(input.Format); err != nil {
+			return nil, GetLifeEventDetailsOutput{}, err
+		}
+		scoped, err := audienceClient(httpClient, input.Audience)
+		if err != nil {
+			return nil, GetLifeEventDetailsOutput{}, err
 		}
 
-		details, err := httpClient.GetLifeEventDetails(ctx, input.URL)
+		details, err := scoped.GetLifeEventDetails(ctx, input.URL)
 		if err != nil {
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					&mcp.TextContent{
-						Text: fmt.Sprintf("ERROR: Unable to retrieve life event details from %s. Reason: %v\n\nDo NOT retry this same URL. Instead, inform the user that this specific page could not be retrieved and suggest they visit the URL directly in their browser, or try searching for alternative life events.", input.URL, err),
-					},
-				},
-				IsError: true,
-			}, GetLifeEventDetailsOutput{}, fmt.Errorf("failed to get life event details from %s: %w", input.URL, err)
+			return nil, GetLifeEventDetailsOutput{}, fmt.Errorf("failed to get life event details from %s: %w", input.URL, err)
 		}
 
 		output := GetLifeEventDetailsOutput{
@@ -332,19 +723,39 @@ func registerGetLifeEventDetails(server *mcp.Server, httpClient *client.Client)
 		for i, s := range details.Sections {
 			output.Sections[i] = LifeEventSectionOutput{
 				Title:   s.Title,
-				Content: s.Content,
+				Content: selectContent(input.Format, s.Content, s.Markdown),
+				Tables:  tablesOutput(s.Tables),
 			}
 		}
 
 		return &mcp.CallToolResult{
 			Content: []mcp.Content{
 				&mcp.TextContent{
-					Text: fmt.Sprintf("Retrieved life event: %s\n\nThis guide contains %d sections with detailed information.\n\nSource: %s\n\nIMPORTANT: Always provide this source URL to the user so they can access the original page.", details.Title, len(details.Sections), details.URL),
+					Text: snapshotNote(scoped, fmt.Sprintf("Retrieved life event: %s\n\nThis guide contains %d sections with detailed information.\n\nSource: %s\n\nIMPORTANT: Always provide this source URL to the user so they can access the original page.", details.Title, len(details.Sections), details.URL)),
 				},
 			},
 		}, output, nil
 	}
 
-	mcp.AddTool(server, tool, handler)
-	return nil
+	return addTool(server, tool, handler)
+}
+
+// noteSource is a client whose results may not be current.
+type noteSource interface {
+	Name() string
+	SnapshotDate() time.Time
+	Degraded() bool
+}
+
+// snapshotNote appends the date of the offline snapshot served by src, or a
+// warning when its website is down and results come from the cache, to text,
+// so the model can tell the user how current the information is.
+func snapshotNote(src noteSource, text string) string {
+	if date := src.SnapshotDate(); !date.IsZero() {
+		return fmt.Sprintf("%s\n\nNOTE: This information comes from an offline snapshot taken on %s and may be out of date.", text, date.Format("2006-01-02"))
+	}
+	if src.Degraded() {
+		return fmt.Sprintf("%s\n\nNOTE: %s is temporarily unavailable. This information comes from the cache and may be out of date.", text, src.Name())
+	}
+	return text
 }
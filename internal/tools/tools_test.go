@@ -1,6 +1,8 @@
 package tools
 
 import (
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -42,6 +44,10 @@ func TestRegisterTools(t *testing.T) {
 			if err := registerListCategories(server, httpClient); (err != nil) != tt.wantErr {
 				t.Errorf("registerListCategories() error = %v, wantErr %v", err, tt.wantErr)
 			}
+
+			if err := registerBrowseCategory(server, httpClient); (err != nil) != tt.wantErr {
+				t.Errorf("registerBrowseCategory() error = %v, wantErr %v", err, tt.wantErr)
+			}
 		})
 	}
 }
@@ -99,3 +105,140 @@ func TestSearchProcedures(t *testing.T) {
 		})
 	}
 }
+
+func TestSectionsOutput(t *testing.T) {
+	sections := []client.ArticleSection{
+		{
+			Level:      2,
+			Title:      "Où faire la demande ?",
+			Paragraphs: []string{"En mairie."},
+			Sections: []client.ArticleSection{
+				{
+					Level: 3,
+					Title: "Prendre rendez-vous",
+					Lists: [][]string{{"Photo", "Justificatif"}},
+					Sections: []client.ArticleSection{
+						{Level: 4, Title: "En ligne"},
+					},
+				},
+			},
+		},
+		{Level: 2, Title: "Coût"},
+	}
+
+	got := sectionsOutput(sections)
+
+	if len(got) != 2 || got[0].Number != "1" || got[1].Number != "2" || got[1].Title != "Coût" {
+		t.Fatalf("sectionsOutput() = %+v", got)
+	}
+	if len(got[0].Subsections) != 1 {
+		t.Fatalf("section 1 subsections = %+v", got[0].Subsections)
+	}
+	sub := got[0].Subsections[0]
+	if sub.Number != "1.1" || sub.Title != "Prendre rendez-vous" || len(sub.Lists) != 1 || len(sub.Lists[0]) != 2 {
+		t.Errorf("subsection = %+v", sub)
+	}
+	if len(sub.Subsections) != 1 || sub.Subsections[0].Number != "1.1.1" || sub.Subsections[0].Title != "En ligne" {
+		t.Errorf("subsection subsections = %+v", sub.Subsections)
+	}
+	if got[1].Subsections != nil {
+		t.Errorf("section 2 subsections = %+v, want none", got[1].Subsections)
+	}
+
+	want := "1. Où faire la demande ?\n  1.1. Prendre rendez-vous\n    1.1.1. En ligne\n2. Coût\n"
+	if outline := sectionsOutline(got); outline != want {
+		t.Errorf("sectionsOutline() = %q, want %q", outline, want)
+	}
+}
+
+func TestSelectContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		markdown string
+		want     string
+		wantErr  bool
+	}{
+		{name: "default is text", format: "", markdown: "**md**", want: "text"},
+		{name: "text", format: "text", markdown: "**md**", want: "text"},
+		{name: "markdown", format: "markdown", markdown: "**md**", want: "**md**"},
+		{name: "markdown unavailable", format: "markdown", markdown: "", want: "text"},
+		{name: "unknown format", format: "html", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateFormat(tt.format); (err != nil) != tt.wantErr {
+				t.Fatalf("validateFormat(%q) error = %v, wantErr %v", tt.format, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := selectContent(tt.format, "text", tt.markdown); got != tt.want {
+				t.Errorf("selectContent() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAudienceClient(t *testing.T) {
+	httpClient := client.New(30 * time.Second)
+
+	pro, err := audienceClient(httpClient, "professionnels")
+	if err != nil {
+		t.Fatalf("audienceClient() error = %v", err)
+	}
+	if pro.Audience() != client.AudienceProfessionnels {
+		t.Errorf("Audience() = %q, want %q", pro.Audience(), client.AudienceProfessionnels)
+	}
+
+	def, err := audienceClient(httpClient, "")
+	if err != nil {
+		t.Fatalf("audienceClient() with default audience error = %v", err)
+	}
+	if def.Audience() != client.AudienceParticuliers {
+		t.Errorf("default Audience() = %q, want %q", def.Audience(), client.AudienceParticuliers)
+	}
+
+	if _, err := audienceClient(httpClient, "unknown"); err == nil {
+		t.Error("audienceClient() with unknown audience should fail")
+	}
+}
+
+func TestPaginationNote(t *testing.T) {
+	if note := paginationNote(0, 1, ""); note != "" {
+		t.Errorf("paginationNote() for a single page = %q, want empty", note)
+	}
+
+	note := paginationNote(42, 2, "abc")
+	if !strings.Contains(note, "42 results") || !strings.Contains(note, `cursor "abc"`) {
+		t.Errorf("paginationNote() = %q, want total and cursor", note)
+	}
+}
+
+func TestEmptySearchMessage(t *testing.T) {
+	tests := []struct {
+		name        string
+		reason      client.EmptyReason
+		suggestions []string
+		err         error
+		want        []string
+	}{
+		{"no match", client.EmptyNoMatch, nil, nil, []string{"No results match 'zzzz'", "more general"}},
+		{"suggestions", client.EmptyNoMatch, []string{"pizza", "jazz"}, nil, []string{"No results match", "suggests: pizza, jazz"}},
+		{"site error", client.EmptySiteError, nil, errors.New("HTTP error 503"), []string{"search on example.gouv.fr failed", "HTTP error 503"}},
+		{"parse failure", client.EmptyParseFailure, nil, nil, []string{"could be read", "layout may have changed"}},
+		{"offline", client.EmptyOffline, nil, nil, []string{"unavailable offline", "Do not retry"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := emptySearchMessage("example.gouv.fr", "zzzz", tt.reason, tt.suggestions, tt.err)
+			for _, want := range tt.want {
+				if !strings.Contains(message, want) {
+					t.Errorf("emptySearchMessage() = %q, want it to contain %q", message, want)
+				}
+			}
+		})
+	}
+}